@@ -4,6 +4,8 @@ import (
 	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
 	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto/reflectwalktinygo"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 var _ reflectwalktinygo.PrimitiveWalker = &childrenCollectorWalker{}
@@ -14,16 +16,38 @@ var _ reflectwalktinygo.ArrayWalker = &childrenCollectorWalker{}
 var _ reflectwalktinygo.StructWalker = &childrenCollectorWalker{}
 var _ reflectwalktinygo.EnterExitWalker = &childrenCollectorWalker{}
 var _ reflectwalktinygo.PointerWalker = &childrenCollectorWalker{}
+var _ reflectwalktinygo.PointerValueWalker = &childrenCollectorWalker{}
+var _ reflectwalktinygo.RevisitWalker = &childrenCollectorWalker{}
 
 type childrenCollectorWalker struct {
 	// PARAMETERS
 	sdfCoreType  reflect.Type // Type of the core SDF implementation
 	castCoreType func(interface{}) (SDFCore, bool)
+	// ancestors are the identities (see identity) of the SDF and its ancestors, which are never its children.
+	ancestors []interface{}
+	// perturbCore and pruneUnused are inherited by the automatic children (see SDF).
+	perturbCore func(child interface{}, offset float32) interface{}
+	pruneUnused bool
+	// parentName is the name of the SDF, which prefixes the names of the automatic children.
+	parentName string
 	// OUTPUT
 	// children is the list of children of the SDF that will be returned.
 	children []sdf_viewer_go.SDF
+	// found are the identities of the found children and ancestors, to surface shared children only once.
+	found map[interface{}]bool
+	// childIndex is the index in children of each found identity.
+	childIndex map[interface{}]int
+	// locations are the settable values (see settableValue) where each child was found, which are invalid if unknown.
+	locations [][]reflect.Value
 	// TEMPORARY
 	curDepthLevel, skipEntryUntilLevel int
+	// prefix, segments (one per depth level) and pending (the next segment) form the field path of the current
+	// location (see locator).
+	prefix   string
+	segments []string
+	pending  string
+	// transparent is whether each struct being walked (innermost last) is transparent (see isTransparent).
+	transparent []bool
 }
 
 func (c *childrenCollectorWalker) Primitive(value reflect.Value) error {
@@ -32,8 +56,7 @@ func (c *childrenCollectorWalker) Primitive(value reflect.Value) error {
 }
 
 func (c *childrenCollectorWalker) Interface(value reflect.Value) error {
-	_ = c.checkValue(value)
-	return nil
+	return c.checkValue(value) // May skip the contents of a found child
 }
 
 func (c *childrenCollectorWalker) Map(m reflect.Value) error {
@@ -42,6 +65,7 @@ func (c *childrenCollectorWalker) Map(m reflect.Value) error {
 }
 
 func (c *childrenCollectorWalker) MapElem(_, k, v reflect.Value) error {
+	c.pending = "[" + mapKeyString(k) + "]"
 	_ = c.checkValue(k)
 	_ = c.checkValue(v)
 	return nil
@@ -52,7 +76,8 @@ func (c *childrenCollectorWalker) Slice(value reflect.Value) error {
 	return nil
 }
 
-func (c *childrenCollectorWalker) SliceElem(_ int, value reflect.Value) error {
+func (c *childrenCollectorWalker) SliceElem(i int, value reflect.Value) error {
+	c.pending = "[" + strconv.Itoa(i) + "]"
 	_ = c.checkValue(value)
 	return nil
 }
@@ -62,32 +87,53 @@ func (c *childrenCollectorWalker) Array(value reflect.Value) error {
 	return nil
 }
 
-func (c *childrenCollectorWalker) ArrayElem(_ int, value reflect.Value) error {
+func (c *childrenCollectorWalker) ArrayElem(i int, value reflect.Value) error {
+	c.pending = "[" + strconv.Itoa(i) + "]"
 	_ = c.checkValue(value)
 	return nil
 }
 
 func (c *childrenCollectorWalker) Struct(value reflect.Value) error {
+	c.transparent = append(c.transparent, isTransparent(value))
 	return c.checkValue(value)
 }
 
-func (c *childrenCollectorWalker) StructField(_ reflect.StructField, _ reflect.Value) error {
+func (c *childrenCollectorWalker) StructField(field reflect.StructField, _ reflect.Value) error {
+	if !c.transparent[len(c.transparent)-1] {
+		c.pending = field.Name
+	}
 	return nil // Will be called again as whatever type it is, so we don't care now.
 }
 
-func (c *childrenCollectorWalker) Enter(_ reflectwalktinygo.Location) error {
+func (c *childrenCollectorWalker) Enter(loc reflectwalktinygo.Location) error {
 	c.curDepthLevel++
+	segment := ""
+	if loc != reflectwalktinygo.MapKey { // The key of the map element is kept for its value
+		segment, c.pending = c.pending, ""
+	}
+	c.segments = append(c.segments, segment)
 	return nil
 }
 
-func (c *childrenCollectorWalker) Exit(_ reflectwalktinygo.Location) error {
+func (c *childrenCollectorWalker) Exit(loc reflectwalktinygo.Location) error {
+	if loc == reflectwalktinygo.Struct {
+		c.transparent = c.transparent[:len(c.transparent)-1]
+	}
 	c.curDepthLevel--
+	c.segments = c.segments[:len(c.segments)-1]
 	if c.curDepthLevel < c.skipEntryUntilLevel {
 		c.skipEntryUntilLevel = 0
 	}
 	return nil
 }
 
+func (c *childrenCollectorWalker) Pointer(value reflect.Value) error {
+	if c.curDepthLevel <= 1 || value.IsNil() {
+		return nil // The root is not a child of itself, and nil pointers are not children
+	}
+	return c.checkValue(value) // Pointers stored as interface{} (or concrete types) may also be children
+}
+
 func (c *childrenCollectorWalker) PointerEnter(_ bool, _ reflect.Value) error {
 	//_ = c.checkValue(value)
 	return nil
@@ -97,6 +143,10 @@ func (c *childrenCollectorWalker) PointerExit(_ bool) error {
 	return nil
 }
 
+func (c *childrenCollectorWalker) Revisit(_ reflect.Value, _ bool) error {
+	return nil // Cycles and shared values are skipped, as their children were already found
+}
+
 func (c *childrenCollectorWalker) checkValue(value reflect.Value) error {
 	// Stop recursion if a parent was already found as a child of the root node.
 	if c.skipEntryUntilLevel > 0 && c.curDepthLevel > c.skipEntryUntilLevel {
@@ -111,15 +161,38 @@ func (c *childrenCollectorWalker) checkValue(value reflect.Value) error {
 
 	// Look for the core SDF implementations and register them automatically as children.
 	coreImpl, coreImplOk := interfaceAndImplementsHint(value, c.sdfCoreType)
+	return c.checkInterface(coreImpl, coreImplOk, value)
+}
+
+// checkInterface registers the interface found at the location as a child if it is a core SDF implementation (see
+// interfaceAndImplementsHint for coreImplOk), returning SkipEntry if it should not be explored further.
+func (c *childrenCollectorWalker) checkInterface(coreImpl interface{}, coreImplOk *bool, value reflect.Value) error {
 	if s, ok := c.castCoreType(coreImpl); coreImplOk != nil && *coreImplOk || ok {
+		if id := identity(s.SDFCoreChildrenRoot()); id != nil {
+			if c.found[id] { // Shared child (already found) or ancestor (a cycle)
+				if i, ok := c.childIndex[id]; ok {
+					c.locations[i] = append(c.locations[i], settableValue(value))
+				}
+				c.skipEntryUntilLevel = c.curDepthLevel
+				return reflectwalktinygo.SkipEntry
+			}
+			c.found[id] = true
+			c.childIndex[id] = len(c.children)
+		}
 		if s2, ok := s.(sdf_viewer_go.SDF); ok {
 			// Already and advanced SDF, keep it
 			//log.Printf("Found ADVANCED SDF child2: %#+v\n", s2)
-			c.foundChild(s2)
+			c.foundChild(s2, value)
 		} else {
 			// Automatic (default) conversion of core type to advanced type
 			//log.Printf("Found core SDF child: %#+v\n", s)
-			c.foundChild(NewSDF(s, c.sdfCoreType, c.castCoreType))
+			child := NewSDF(s, c.sdfCoreType, c.castCoreType)
+			child.ancestors = c.ancestors
+			child.PerturbCore = c.perturbCore
+			child.PruneUnusedChildren = c.pruneUnused
+			child.parentName = c.parentName
+			child.locator = c.locator()
+			c.foundChild(child, value)
 		}
 		return reflectwalktinygo.SkipEntry // No more recursion TODO: implement this for all type callbacks
 	}
@@ -128,8 +201,96 @@ func (c *childrenCollectorWalker) checkValue(value reflect.Value) error {
 	return nil
 }
 
-func (c *childrenCollectorWalker) foundChild(s sdf_viewer_go.SDF) {
+func (c *childrenCollectorWalker) foundChild(s sdf_viewer_go.SDF, location reflect.Value) {
 	c.children = append(c.children, s)
+	c.locations = append(c.locations, []reflect.Value{settableValue(location)})
 	c.skipEntryUntilLevel = c.curDepthLevel // Ignore all children of this node
 	//fmt.Printf("Found child: %#+v\n", s)
 }
+
+// identity returns a comparable key that identifies the value behind a pointer, map or slice (as nodes are usually
+// pointers), or nil for other values, which are never considered the same node.
+func identity(v interface{}) interface{} {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if value.Pointer() != 0 {
+			return identityKey{typ: value.Type(), ptr: value.Pointer()}
+		}
+	}
+	return nil
+}
+
+type identityKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// locator returns the field path of the current location in the SDF (e.g. "sdf[2]"), which names its children.
+func (c *childrenCollectorWalker) locator() string {
+	res := c.prefix
+	for _, segment := range c.segments {
+		res = joinField(res, segment)
+	}
+	return joinField(res, c.pending)
+}
+
+// isTransparent returns true for the structs of this package (or the wrappers that embed them) and the core SDFs, which
+// may wrap children, but whose fields are not part of their names.
+func isTransparent(value reflect.Value) bool {
+	if !value.CanAddr() {
+		return false
+	}
+	switch reflect.NewAt(value.Type(), value.Addr().UnsafePointer()).Interface().(type) {
+	case wrapper, SDFCore:
+		return true
+	default:
+		return false
+	}
+}
+
+// joinField appends a field name or an index (e.g. "[2]") to a field path.
+func joinField(path, field string) string {
+	switch {
+	case field == "":
+		return path
+	case path == "" || field[0] == '[':
+		return path + field
+	default:
+		return path + "." + field
+	}
+}
+
+// mapKeyString formats the key of a map element for field paths.
+func mapKeyString(k reflect.Value) string {
+	switch k.Kind() {
+	case reflect.String:
+		return strconv.Quote(k.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool())
+	case reflect.Interface:
+		if !k.IsNil() {
+			return mapKeyString(k.Elem())
+		}
+	}
+	return "?"
+}
+
+// typeName returns the name of the type of the node, without its package or pointers (e.g. "UnionSDF3").
+func typeName(node interface{}) string {
+	t := reflect.TypeOf(node)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "nil"
+	}
+	if name := t.Name(); name != "" {
+		return name
+	}
+	return strings.TrimPrefix(t.String(), "*")
+}
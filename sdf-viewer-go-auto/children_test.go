@@ -0,0 +1,370 @@
+package sdf_viewer_go_auto
+
+import (
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+// shape plays the role of the SDF3 interface of the supported libraries.
+type shape interface {
+	Eval(p [3]float32) float32
+}
+
+// node is a sphere (along the X axis), and the union of the shapes that it stores in any way (possibly cyclic).
+type node struct {
+	radius   float32
+	x        float32
+	children []shape
+	self     *node
+	parent   shape
+	byName   map[string]shape
+	misc     interface{}
+}
+
+func (n *node) Eval(p [3]float32) float32 {
+	return (p[0]-n.x)*(p[0]-n.x) + p[1]*p[1] + p[2]*p[2] - n.radius*n.radius
+}
+
+// shapeCore adapts a shape to SDFCore.
+type shapeCore struct {
+	shape shape
+}
+
+// boundedShape is a shape that knows its bounding box (the others are inside the unit cube).
+type boundedShape interface {
+	bounds() [2][3]float32
+}
+
+func (c *shapeCore) SDFCoreEval(p [3]float32) float32 { return c.shape.Eval(p) }
+func (c *shapeCore) SDFCoreChildrenRoot() interface{} { return c.shape }
+func (c *shapeCore) SDFCoreAABB() [2][3]float32 {
+	if b, ok := c.shape.(boundedShape); ok {
+		return b.bounds()
+	}
+	return [2][3]float32{{-1, -1, -1}, {1, 1, 1}}
+}
+
+func newShapeSDF(s shape) *SDF {
+	return NewSDF(&shapeCore{s}, reflect.TypeOf((*shape)(nil)).Elem(), func(v interface{}) (SDFCore, bool) {
+		s, ok := v.(shape)
+		if !ok {
+			return nil, false
+		}
+		return &shapeCore{s}, true
+	})
+}
+
+// childShapes returns the shapes of the children of the SDF.
+func childShapes(s *SDF) []shape {
+	var res []shape
+	for _, child := range s.Children() {
+		res = append(res, child.(*SDF).SDF.(*shapeCore).shape)
+	}
+	return res
+}
+
+// assertShapes checks that the shapes are the expected ones, in any order.
+func assertShapes(t *testing.T, actual []shape, expected ...shape) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d children, got %d", len(expected), len(actual))
+	}
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			found = found || a == e
+		}
+		if !found {
+			t.Fatalf("expected child %p in %v", e, actual)
+		}
+	}
+}
+
+func TestChildrenSelfReference(t *testing.T) {
+	child := &node{radius: 0.5}
+	child.self = child
+	root := &node{radius: 1, children: []shape{child}}
+	root.self = root
+	s := newShapeSDF(root)
+	assertShapes(t, childShapes(s), child)
+	assertShapes(t, childShapes(s.Children()[0].(*SDF)))
+}
+
+func TestChildrenShared(t *testing.T) {
+	a, b := &node{radius: 0.5}, &node{radius: 0.25}
+	shared := &node{radius: 0.1}
+	a.children = []shape{shared}
+	b.children = []shape{shared}
+	root := &node{children: []shape{a, b, a}, byName: map[string]shape{"a": a, "b": b}}
+	s := newShapeSDF(root)
+	assertShapes(t, childShapes(s), a, b)
+	// Shared subtrees are still children of each of their parents
+	for _, child := range s.Children() {
+		assertShapes(t, childShapes(child.(*SDF)), shared)
+	}
+}
+
+func TestChildrenAncestorReference(t *testing.T) {
+	root := &node{}
+	child := &node{parent: root}
+	grandchild := &node{parent: child, misc: root}
+	child.children = []shape{grandchild}
+	root.children = []shape{child}
+	s := newShapeSDF(root)
+	assertShapes(t, childShapes(s), child)
+	childSDF := s.Children()[0].(*SDF)
+	assertShapes(t, childShapes(childSDF), grandchild)
+	assertShapes(t, childShapes(childSDF.Children()[0].(*SDF)))
+}
+
+func TestChildrenCyclicMapsAndSlices(t *testing.T) {
+	a, b := &node{radius: 0.5}, &node{radius: 0.25}
+	m := map[string]interface{}{"a": a}
+	m["m"] = m
+	slice := make([]interface{}, 3)
+	slice[0], slice[1], slice[2] = slice, b, m
+	root := &node{misc: slice, byName: map[string]shape{"b": b}}
+	assertShapes(t, childShapes(newShapeSDF(root)), a, b)
+}
+
+// union is the union of its shapes, which also stores an unused shape.
+type union struct {
+	shapes []shape
+	unused shape
+}
+
+func (u *union) Eval(p [3]float32) float32 {
+	res := float32(math.Inf(1))
+	for _, s := range u.shapes {
+		res = float32(math.Min(float64(res), float64(s.Eval(p))))
+	}
+	return res
+}
+
+// offsetShape offsets the distances of a shape.
+type offsetShape struct {
+	shape  shape
+	offset float32
+}
+
+func (o *offsetShape) Eval(p [3]float32) float32 { return o.shape.Eval(p) + o.offset }
+
+func TestChildrenPruneUnused(t *testing.T) {
+	a, b, unused := &node{radius: 1}, &node{radius: 1, x: 1}, &node{radius: 2}
+	inner := &union{shapes: []shape{b}, unused: unused}
+	root := &union{shapes: []shape{a, inner}, unused: unused}
+	s := newShapeSDF(root)
+	assertShapes(t, childShapes(s), a, inner, unused)
+
+	s = newShapeSDF(root)
+	s.PruneUnusedChildren = true
+	s.PerturbCore = func(child interface{}, offset float32) interface{} {
+		return shape(&offsetShape{child.(shape), offset})
+	}
+	assertShapes(t, childShapes(s), a, inner)
+	// Inherited by the automatic children
+	assertShapes(t, childShapes(s.Children()[1].(*SDF)), b)
+	if root.shapes[0] != a || root.unused != unused || inner.shapes[0] != b {
+		t.Fatal("expected the perturbed children to be restored")
+	}
+}
+
+// ball is a sphere with exact distances.
+type ball struct {
+	center [3]float32
+	radius float32
+}
+
+func (b *ball) Eval(p [3]float32) float32 {
+	dx, dy, dz := p[0]-b.center[0], p[1]-b.center[1], p[2]-b.center[2]
+	return float32(math.Sqrt(float64(dx*dx+dy*dy+dz*dz))) - b.radius
+}
+
+func (b *ball) bounds() [2][3]float32 {
+	return [2][3]float32{
+		{b.center[0] - b.radius, b.center[1] - b.radius, b.center[2] - b.radius},
+		{b.center[0] + b.radius, b.center[1] + b.radius, b.center[2] + b.radius},
+	}
+}
+
+// slab is a thin plate (along the XY plane), which declares a bigger bounding box.
+type slab struct {
+	halfThickness, halfSide float32
+}
+
+func (s *slab) Eval(p [3]float32) float32 { return float32(math.Abs(float64(p[2]))) - s.halfThickness }
+
+func (s *slab) bounds() [2][3]float32 {
+	return [2][3]float32{{-s.halfSide, -s.halfSide, -s.halfSide}, {s.halfSide, s.halfSide, s.halfSide}}
+}
+
+// difference subtracts the cutter from the base shape.
+type difference struct {
+	base, cutter shape
+}
+
+func (d *difference) Eval(p [3]float32) float32 {
+	return float32(math.Max(float64(d.base.Eval(p)), float64(-d.cutter.Eval(p))))
+}
+
+func (d *difference) bounds() [2][3]float32 { return d.base.(boundedShape).bounds() }
+
+func TestChildrenPruneSmallCutter(t *testing.T) {
+	// The hole is much smaller than the distance between points spread over the bounding box of the parent, and it only
+	// changes the distances close to it (as the plate is thin)
+	base, cutter := &slab{halfThickness: 0.1, halfSide: 100}, &ball{center: [3]float32{37, -12, 0}, radius: 0.05}
+	s := newShapeSDF(&difference{base: base, cutter: cutter})
+	s.PruneUnusedChildren = true
+	s.PerturbCore = func(child interface{}, offset float32) interface{} {
+		return shape(&offsetShape{child.(shape), offset})
+	}
+	assertShapes(t, childShapes(s), base, cutter)
+}
+
+// pair is the union of two shapes, which also stores an unused shape.
+type pair struct {
+	a, b, unused shape
+}
+
+func (p *pair) Eval(pos [3]float32) float32 {
+	return float32(math.Min(float64(p.a.Eval(pos)), float64(p.b.Eval(pos))))
+}
+
+func TestChildrenExtractor(t *testing.T) {
+	a, b, unused := &node{radius: 1}, &node{radius: 0.5}, &node{radius: 2}
+	p := &pair{a: a, b: b, unused: unused}
+	typeName := reflect.TypeOf(pair{}).PkgPath() + ".pair"
+	defer delete(childrenExtractors, typeName)
+
+	fields := []FieldLayout{
+		{Name: "a", Offset: unsafe.Offsetof(pair{}.a), Kind: reflect.Interface},
+		{Name: "b", Offset: unsafe.Offsetof(pair{}.b), Kind: reflect.Interface},
+		{Name: "unused", Offset: unsafe.Offsetof(pair{}.unused), Kind: reflect.Interface},
+	}
+	// Outdated extractors are ignored, so reflection finds all shapes
+	RegisterChildrenExtractor(typeName, unsafe.Sizeof(pair{})+1, fields, nil)
+	assertShapes(t, childShapes(newShapeSDF(p)), a, b, unused)
+	reordered := []FieldLayout{fields[1], fields[0], fields[2]}
+	reordered[0].Offset, reordered[1].Offset = reordered[1].Offset, reordered[0].Offset
+	RegisterChildrenExtractor(typeName, unsafe.Sizeof(pair{}), reordered, nil)
+	assertShapes(t, childShapes(newShapeSDF(p)), a, b, unused)
+	retyped := append([]FieldLayout{}, fields...)
+	retyped[2].Kind = reflect.Ptr
+	RegisterChildrenExtractor(typeName, unsafe.Sizeof(pair{}), retyped, nil)
+	assertShapes(t, childShapes(newShapeSDF(p)), a, b, unused)
+
+	RegisterChildrenExtractor(typeName, unsafe.Sizeof(pair{}), fields, func(node unsafe.Pointer) []NodeField {
+		n := (*pair)(node)
+		return []NodeField{{Name: "a", Pointer: &n.a}, {Name: "b", Pointer: &n.b}}
+	})
+	s := newShapeSDF(p)
+	assertShapes(t, childShapes(s), a, b)
+	if name := s.Children()[1].Name(); name != "pair/b:node" {
+		t.Fatalf("expected the name pair/b:node, got %q", name)
+	}
+}
+
+// labeledPair is a pair that lists its children.
+type labeledPair struct {
+	pair
+}
+
+func (p *labeledPair) SDFChildren() []LabeledChild {
+	return []LabeledChild{{Label: "left", Child: p.a}, {Label: "right", Child: p.b}}
+}
+
+func TestChildrenProvider(t *testing.T) {
+	a, b, unused := &node{radius: 1}, &node{radius: 0.5}, &node{radius: 2}
+	s := newShapeSDF(&labeledPair{pair{a: a, b: b, unused: unused}})
+	assertShapes(t, childShapes(s), a, b)
+	for i, expected := range []string{"labeledPair/left:node", "labeledPair/right:node"} {
+		if name := s.Children()[i].Name(); name != expected {
+			t.Fatalf("expected the name of child %d to be %q, got %q", i, expected, name)
+		}
+	}
+
+	typeName := reflect.TypeOf(pair{}).PkgPath() + ".pair"
+	RegisterChildrenProvider(typeName, func(node interface{}) []LabeledChild {
+		return []LabeledChild{{Label: "cutter", Child: node.(*pair).unused}}
+	})
+	defer delete(childrenProviders, typeName)
+	assertShapes(t, childShapes(newShapeSDF(&pair{a: a, b: b, unused: unused})), unused)
+}
+
+// sdfPair is a pair that lists its children as SDFs, with duplicates and itself.
+type sdfPair struct {
+	pair
+}
+
+func (p *sdfPair) SDFChildren() []LabeledChild {
+	return []LabeledChild{{Label: "left", Child: newShapeSDF(p.a)}, {Label: "again", Child: newShapeSDF(p.a)},
+		{Label: "self", Child: newShapeSDF(p)}, {Label: "right", Child: p.b}}
+}
+
+func TestChildrenProviderSDFs(t *testing.T) {
+	a, b := &node{radius: 1}, &node{radius: 0.5}
+	s := newShapeSDF(&sdfPair{pair{a: a, b: b}})
+	assertShapes(t, childShapes(s), a, b)
+	for i, expected := range []string{"sdfPair/left:node", "sdfPair/right:node"} {
+		if name := s.Children()[i].Name(); name != expected {
+			t.Fatalf("expected the name of child %d to be %q, got %q", i, expected, name)
+		}
+	}
+}
+
+// coloredSDF is an SDF of any other type, which paints the SDF that it wraps.
+type coloredSDF struct {
+	sdf_viewer_go.SDF
+	color [3]float32
+}
+
+func (c *coloredSDF) Sample(p [3]float32, distanceOnly bool) sdf_viewer_go.SDFSample {
+	sample := c.SDF.Sample(p, distanceOnly)
+	sample.Color = c.color
+	return sample
+}
+
+// coloredPair is a pair that lists its first child as a coloredSDF.
+type coloredPair struct {
+	pair
+}
+
+func (p *coloredPair) SDFChildren() []LabeledChild {
+	return []LabeledChild{{Label: "left", Child: &coloredSDF{newShapeSDF(p.a), [3]float32{0, 1, 0}}},
+		{Label: "right", Child: p.b}}
+}
+
+func TestChildrenProviderOtherSDFs(t *testing.T) {
+	s := newShapeSDF(&coloredPair{pair{a: &node{radius: 1}, b: &node{radius: 0.5, x: 3}}})
+	if sample := s.Sample([3]float32{-0.5, 0, 0}, false); sample.Color != [3]float32{0, 1, 0} {
+		t.Fatalf("expected the color of the closest child, got %v", sample.Color)
+	}
+	samples := make([]sdf_viewer_go.SDFSample, 2)
+	s.SampleBatch([][3]float32{{-0.5, 0, 0}, {3, 0, 0}}, false, samples)
+	if samples[0].Color != [3]float32{0, 1, 0} || samples[1].Color == [3]float32{0, 1, 0} {
+		t.Fatalf("expected the colors of the closest children, got %v and %v", samples[0].Color, samples[1].Color)
+	}
+}
+
+func TestChildrenNames(t *testing.T) {
+	leaf := &node{}
+	a := &node{children: []shape{leaf}}
+	root := &node{children: []shape{a}, parent: &node{}, byName: map[string]shape{"z": &node{}, "y": &node{}},
+		misc: newShapeSDF(&node{})} // The fields of the wrappers are not part of the names
+	for run := 0; run < 2; run++ { // The same on every run
+		s := newShapeSDF(root)
+		var names []string
+		for _, child := range s.Children() {
+			names = append(names, child.Name())
+		}
+		names = append(names, s.Children()[0].Children()[0].Name())
+		expected := []string{"node/children[0]:node", "node/parent:node", `node/byName["y"]:node`,
+			`node/byName["z"]:node`, "node/misc:node", "node/children[0]:node/children[0]:node"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("expected the names %q, got %q", expected, names)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+// Package childrengen generates the children extractors (see sdf_viewer_go_auto.RegisterChildrenExtractor) of the
+// node types of an SDF library, so that their children are found without reflection. It is only used by `go generate`.
+//
+// The fields of the nodes are usually unexported, so the generated code reads them through structs with the same
+// memory layout (mirrors), which are checked against the size and the fields (names, offsets and kinds) of the real
+// structs before being used.
+package childrengen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// AutoImportPath is the import path of the package that registers the extractors.
+const AutoImportPath = "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
+
+// Generate returns the source of a file of package pkgName that registers the extractors of the struct types of the
+// library at importPath that implement its interface with the given name (e.g. SDF3), and of the types that they may
+// store in other interfaces of the library (e.g. SDF2). Types that can't be handled (e.g. generic or recursive types,
+// or maps with children) are skipped, as reflection still works for them.
+func Generate(importPath, iface, pkgName string) (string, error) {
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(importPath)
+	if err != nil {
+		return "", err
+	}
+	obj, ok := pkg.Scope().Lookup(iface).(*types.TypeName)
+	if !ok || !types.IsInterface(obj.Type()) {
+		return "", fmt.Errorf("%s.%s is not an interface", importPath, iface)
+	}
+	g := &generator{pkg: pkg, iface: obj.Type(), status: map[types.Type]status{}, mirrors: map[*types.Named]string{},
+		imports: map[string]string{AutoImportPath: "sdfviewergoauto", "unsafe": "unsafe"}}
+	return g.generate(pkgName)
+}
+
+// status is the classification of a type: whether its values can be explored by the generated code, and whether they
+// may store children.
+type status struct {
+	ok, locations bool
+}
+
+type generator struct {
+	pkg   *types.Package
+	iface types.Type
+	// imports are the names of the imported packages, by path.
+	imports map[string]string
+	// status memoizes classify, and is not ok while a type is being classified (for recursive types).
+	status map[types.Type]status
+	// mirrors are the names of the generated mirror structs, with their definitions in mirrorDefs.
+	mirrors    map[*types.Named]string
+	mirrorDefs []string
+	// containers are the interfaces of the library found while classifying, whose implementations are also registered.
+	containers map[*types.Named]bool
+}
+
+func (g *generator) generate(pkgName string) (string, error) {
+	// Register the implementations of the interface, and of the interfaces that they store, until no more are found
+	var names []string
+	for _, name := range g.pkg.Scope().Names() {
+		if named, ok := g.pkg.Scope().Lookup(name).(*types.TypeName); ok && !named.IsAlias() {
+			names = append(names, name)
+		}
+	}
+	interfaces := []types.Type{g.iface}
+	added := map[types.Type]bool{g.iface: true}
+	g.containers = map[*types.Named]bool{}
+	registered := map[string]bool{}
+	var body bytes.Buffer
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			named := g.pkg.Scope().Lookup(name).Type().(*types.Named)
+			if registered[name] || named.TypeParams().Len() > 0 || !implementsAny(named, interfaces) {
+				continue
+			}
+			if _, ok := named.Underlying().(*types.Struct); !ok || !g.classify(named).ok {
+				continue
+			}
+			registered[name] = true
+			changed = true
+			g.register(&body, named)
+		}
+		for container := range g.containers {
+			if !added[container] && container.Obj().Pkg() == g.pkg {
+				interfaces = append(interfaces, container)
+				added[container] = true
+				changed = true
+			}
+		}
+	}
+
+	var sb bytes.Buffer
+	sb.WriteString("// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\nimport (\n", pkgName)
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if name := g.imports[path]; name != path[strings.LastIndex(path, "/")+1:] {
+			fmt.Fprintf(&sb, "\t%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(&sb, "\t%q\n", path)
+		}
+	}
+	sb.WriteString(")\n\nfunc init() {\n")
+	sb.Write(body.Bytes())
+	sb.WriteString("}\n")
+	for _, def := range g.mirrorDefs {
+		sb.WriteString("\n" + def)
+	}
+	res, err := format.Source(sb.Bytes())
+	return string(res), err
+}
+
+// register writes the registration of the extractor of the struct type.
+func (g *generator) register(sb *bytes.Buffer, named *types.Named) {
+	mirror := g.mirror(named)
+	typeName := g.pkg.Path() + "." + named.Obj().Name()
+	fmt.Fprintf(sb, "\tsdfviewergoauto.RegisterChildrenExtractor(%q, unsafe.Sizeof(%s{}), ", typeName, mirror)
+	if s := named.Underlying().(*types.Struct); s.NumFields() > 0 {
+		sb.WriteString("[]sdfviewergoauto.FieldLayout{\n")
+		g.emitFields(sb, mirror+"{}", "", "", s)
+		sb.WriteString("\t}, ")
+	} else {
+		sb.WriteString("nil, ")
+	}
+	if !g.classify(named).locations {
+		sb.WriteString("nil)\n")
+		return
+	}
+	sb.WriteString("func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {\n")
+	fmt.Fprintf(sb, "\t\tn := (*%s)(node)\n", mirror)
+	g.emitLocations(sb, "n", `""`, named, 2)
+	sb.WriteString("\t\treturn res\n\t})\n")
+}
+
+// emitFields writes the layout of the fields of the struct (whose mirror is the value of the expression), at the given
+// path and offset expression, followed by the layout of the fields of the struct fields that are mirrored too.
+func (g *generator) emitFields(sb *bytes.Buffer, expr, prefix, offset string, s *types.Struct) {
+	g.imports["reflect"] = "reflect"
+	for i := 0; i < s.NumFields(); i++ {
+		field := expr + "." + fieldName(s, i)
+		fieldOffset := "unsafe.Offsetof(" + field + ")"
+		if offset != "" {
+			fieldOffset = offset + " + " + fieldOffset
+		}
+		name := prefix + s.Field(i).Name()
+		fmt.Fprintf(sb, "\t\t{Name: %q, Offset: %s, Kind: reflect.%s},\n", name, fieldOffset, kindName(s.Field(i).Type()))
+		if t := s.Field(i).Type(); isStruct(t) && !g.reused(t) {
+			g.emitFields(sb, field, name+".", fieldOffset, t.Underlying().(*types.Struct))
+		}
+	}
+}
+
+// emitLocations writes the code that appends the locations of the children in the value of the expression, named by
+// the given expression.
+func (g *generator) emitLocations(sb *bytes.Buffer, expr, name string, t types.Type, depth int) {
+	if !g.classify(t).locations {
+		return
+	}
+	indent := strings.Repeat("\t", depth)
+	if g.isLocation(t) {
+		fmt.Fprintf(sb, "%sres = append(res, sdfviewergoauto.NodeField{Name: %s, Pointer: &%s})\n", indent, name, expr)
+		return
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		fmt.Fprintf(sb, "%sif %s != nil {\n", indent, expr)
+		g.emitLocations(sb, "(*"+expr+")", name, u.Elem(), depth+1)
+		fmt.Fprintf(sb, "%s}\n", indent)
+	case *types.Slice, *types.Array:
+		index := fmt.Sprintf("i%d", depth)
+		g.imports["strconv"] = "strconv"
+		fmt.Fprintf(sb, "%sfor %s := range %s {\n", indent, index, expr)
+		indexName := appendLiteral(appendLiteral(name, "[")+" + strconv.Itoa("+index+")", "]")
+		g.emitLocations(sb, expr+"["+index+"]", indexName, u.(interface{ Elem() types.Type }).Elem(), depth+1)
+		fmt.Fprintf(sb, "%s}\n", indent)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i).Name()
+			if name != `""` {
+				field = "." + field
+			}
+			g.emitLocations(sb, expr+"."+fieldName(u, i), appendLiteral(name, field), u.Field(i).Type(), depth)
+		}
+	}
+}
+
+// isLocation returns true for the types whose values are returned by the extractors: children and interfaces.
+func (g *generator) isLocation(t types.Type) bool {
+	return types.IsInterface(t) || types.Implements(t, g.iface.Underlying().(*types.Interface))
+}
+
+// classify returns whether the values of the type can be explored by the generated code, and whether they may store
+// children.
+func (g *generator) classify(t types.Type) status {
+	if s, ok := g.status[t]; ok {
+		return s // Including recursive types, which are not ok while being classified
+	}
+	g.status[t] = status{}
+	s := g.classifyUncached(t)
+	g.status[t] = s
+	return s
+}
+
+func (g *generator) classifyUncached(t types.Type) status {
+	if named, ok := t.(*types.Named); ok && named.TypeArgs().Len() > 0 {
+		return status{}
+	}
+	if g.isLocation(t) {
+		if !g.expressible(t) {
+			return status{} // The generated code can't return a pointer to it
+		}
+		if named, ok := t.(*types.Named); ok && types.IsInterface(t) {
+			g.containers[named] = true
+		}
+		return status{ok: true, locations: true}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic, *types.Signature, *types.Chan:
+		return status{ok: true}
+	case *types.Pointer:
+		return g.classify(u.Elem())
+	case *types.Slice:
+		return g.classify(u.Elem())
+	case *types.Array:
+		return g.classify(u.Elem())
+	case *types.Map:
+		if key, value := g.classify(u.Key()), g.classify(u.Elem()); key.locations || value.locations {
+			return status{} // Values of maps are not addressable
+		}
+		return status{ok: true}
+	case *types.Struct:
+		res := status{ok: true}
+		for i := 0; i < u.NumFields(); i++ {
+			field := g.classify(u.Field(i).Type())
+			res.ok = res.ok && field.ok
+			res.locations = res.locations || field.locations
+		}
+		return res
+	default: // e.g. type parameters
+		return status{}
+	}
+}
+
+// expressible returns true if the type can be written exactly in the generated code.
+func (g *generator) expressible(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return true
+	case *types.Named:
+		obj := t.Obj()
+		return obj.Pkg() == nil || obj.Exported() && obj.Pkg().Name() != "main" &&
+			!strings.Contains("/"+obj.Pkg().Path()+"/", "/internal/")
+	case *types.Pointer:
+		return g.expressible(t.Elem())
+	case *types.Slice:
+		return g.expressible(t.Elem())
+	case *types.Array:
+		return g.expressible(t.Elem())
+	case *types.Interface:
+		return t.Empty()
+	default:
+		return false
+	}
+}
+
+// typeExpr writes an expressible type, importing its packages.
+func (g *generator) typeExpr(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if name, ok := g.imports[pkg.Path()]; ok {
+			return name
+		}
+		name := pkg.Name()
+		for taken := true; taken; {
+			taken = false
+			for _, other := range g.imports {
+				if other == name {
+					name += "_"
+					taken = true
+				}
+			}
+		}
+		g.imports[pkg.Path()] = name
+		return name
+	})
+}
+
+// layoutExpr writes a type with the same memory layout, which is the same type for children and interfaces, and a
+// mirror struct for any struct that stores them or that is not expressible.
+func (g *generator) layoutExpr(t types.Type) string {
+	if g.reused(t) {
+		return g.typeExpr(t)
+	}
+	s := g.classify(t)
+	if named, ok := t.(*types.Named); ok && isStruct(t) {
+		return g.mirror(named)
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.String() // Including unsafe.Pointer
+	case *types.Pointer:
+		if s.locations {
+			return "*" + g.layoutExpr(u.Elem())
+		}
+		return "unsafe.Pointer"
+	case *types.Signature, *types.Map, *types.Chan:
+		return "unsafe.Pointer" // A single pointer, which is never read
+	case *types.Interface:
+		return "interface{}" // Two words, which are never read
+	case *types.Slice:
+		if s.locations {
+			return "[]" + g.layoutExpr(u.Elem())
+		}
+		return "[]byte"
+	case *types.Array:
+		return fmt.Sprintf("[%d]%s", u.Len(), g.layoutExpr(u.Elem()))
+	case *types.Struct:
+		return g.structExpr(u)
+	default:
+		panic("unsupported type: " + t.String())
+	}
+}
+
+// reused returns true if the type is used as is by the generated code, instead of a type with the same layout.
+func (g *generator) reused(t types.Type) bool {
+	s := g.classify(t)
+	return s.locations && g.isLocation(t) || !s.locations && g.expressible(t)
+}
+
+// mirror returns the name of the mirror struct of the named struct, defining it if needed.
+func (g *generator) mirror(named *types.Named) string {
+	if name, ok := g.mirrors[named]; ok {
+		return name
+	}
+	name := "layout" + upperFirst(named.Obj().Name())
+	if named.Obj().Pkg() != g.pkg {
+		name = "layout" + upperFirst(named.Obj().Pkg().Name()) + upperFirst(named.Obj().Name())
+	}
+	g.mirrors[named] = name
+	def := fmt.Sprintf("// %s has the memory layout of %s.\ntype %s %s\n", name,
+		types.TypeString(named, (*types.Package).Name), name, g.structExpr(named.Underlying().(*types.Struct)))
+	g.mirrorDefs = append(g.mirrorDefs, def)
+	return name
+}
+
+func (g *generator) structExpr(s *types.Struct) string {
+	if s.NumFields() == 0 {
+		return "struct{}"
+	}
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	for i := 0; i < s.NumFields(); i++ {
+		fmt.Fprintf(&sb, "\t%s %s\n", fieldName(s, i), g.layoutExpr(s.Field(i).Type()))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// fieldName returns the name of the field in the mirror struct, which embeds nothing.
+func fieldName(s *types.Struct, i int) string {
+	if field := s.Field(i); !field.Embedded() && field.Name() != "_" {
+		return field.Name()
+	}
+	return fmt.Sprintf("field%d", i)
+}
+
+// appendLiteral appends a literal to a string expression, merging it with a trailing literal.
+func appendLiteral(expr, literal string) string {
+	if strings.HasSuffix(expr, `"`) {
+		return expr[:len(expr)-1] + literal + `"`
+	}
+	return expr + ` + "` + literal + `"`
+}
+
+// kindName returns the name of the reflect.Kind of the type.
+func kindName(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicKindNames[u.Kind()]
+	case *types.Pointer:
+		return "Ptr"
+	case *types.Slice:
+		return "Slice"
+	case *types.Array:
+		return "Array"
+	case *types.Map:
+		return "Map"
+	case *types.Chan:
+		return "Chan"
+	case *types.Signature:
+		return "Func"
+	case *types.Interface:
+		return "Interface"
+	case *types.Struct:
+		return "Struct"
+	default:
+		panic("unsupported type: " + t.String())
+	}
+}
+
+var basicKindNames = map[types.BasicKind]string{
+	types.Bool: "Bool", types.Int: "Int", types.Int8: "Int8", types.Int16: "Int16", types.Int32: "Int32",
+	types.Int64: "Int64", types.Uint: "Uint", types.Uint8: "Uint8", types.Uint16: "Uint16", types.Uint32: "Uint32",
+	types.Uint64: "Uint64", types.Uintptr: "Uintptr", types.Float32: "Float32", types.Float64: "Float64",
+	types.Complex64: "Complex64", types.Complex128: "Complex128", types.String: "String",
+	types.UnsafePointer: "UnsafePointer",
+}
+
+func implementsAny(t types.Type, interfaces []types.Type) bool {
+	for _, iface := range interfaces {
+		if i := iface.Underlying().(*types.Interface); types.Implements(types.NewPointer(t), i) {
+			return true
+		}
+	}
+	return false
+}
+
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+func upperFirst(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
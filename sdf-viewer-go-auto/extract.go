@@ -0,0 +1,152 @@
+package sdf_viewer_go_auto
+
+import (
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto/reflectwalktinygo"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// ChildrenExtractor returns the fields of a node (the struct behind the pointer) that may store children.
+type ChildrenExtractor func(node unsafe.Pointer) []NodeField
+
+// NodeField is a field of a node that may store children.
+type NodeField struct {
+	// Name is the path of the field in the node (e.g. "sdf[2]"), which names its children.
+	Name string
+	// Pointer points to the field: a value of the SDF interface of the library, which is a child, or of any other
+	// type, which is explored further.
+	Pointer interface{}
+}
+
+// FieldLayout is a field of a struct type, as seen by a children extractor.
+type FieldLayout struct {
+	// Name is the path of the field, including the names of the struct fields that contain it (e.g. "m.x00").
+	Name string
+	// Offset is the offset of the field from the start of the outermost struct.
+	Offset uintptr
+	// Kind is the kind of the type of the field.
+	Kind reflect.Kind
+}
+
+type childrenExtractor struct {
+	size    uintptr
+	fields  []FieldLayout
+	extract ChildrenExtractor
+}
+
+var childrenExtractors = map[string]childrenExtractor{}
+
+// RegisterChildrenExtractor registers how to find the children of the nodes of a struct type (by import path and
+// name, e.g. "github.com/deadsy/sdfx/sdf.UnionSDF3"), instead of exploring them with reflection (which is very
+// limited on TinyGo). A nil extract means that the nodes have no children.
+//
+// The extractor is ignored for structs of any other size, or whose fields don't match the given fields (in order,
+// followed by the fields of the struct fields that it reads), as it is outdated. It is usually called by code generated
+// with the childrengen package.
+func RegisterChildrenExtractor(typeName string, size uintptr, fields []FieldLayout, extract ChildrenExtractor) {
+	childrenExtractors[typeName] = childrenExtractor{size: size, fields: fields, extract: extract}
+}
+
+// WithoutChildrenExtractors calls f with no children extractors registered, so that children are found with reflection
+// (e.g. to check that the registered extractors find the same children).
+func WithoutChildrenExtractors(f func()) {
+	registered := childrenExtractors
+	childrenExtractors = map[string]childrenExtractor{}
+	defer func() { childrenExtractors = registered }()
+	f()
+}
+
+// lookupChildrenExtractor returns the registered extractor for the node (a pointer to a struct) and the pointer.
+func lookupChildrenExtractor(node interface{}) (childrenExtractor, unsafe.Pointer, bool) {
+	value := reflect.ValueOf(node)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Type().Elem().Kind() != reflect.Struct {
+		return childrenExtractor{}, nil, false
+	}
+	e, ok := childrenExtractors[nodeTypeName(node)]
+	if !ok || e.size != value.Type().Elem().Size() {
+		return childrenExtractor{}, nil, false
+	}
+	if n, ok := matchFields(value.Type().Elem(), "", 0, e.fields); !ok || n != len(e.fields) {
+		return childrenExtractor{}, nil, false
+	}
+	return e, value.UnsafePointer(), true
+}
+
+// matchFields checks that the fields of the struct type (at the given path and offset) are the first ones in the list,
+// followed by the fields of their struct types if listed, returning the number of fields that were checked.
+func matchFields(t reflect.Type, prefix string, offset uintptr, fields []FieldLayout) (int, bool) {
+	n := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if n >= len(fields) {
+			return n, false
+		}
+		expected := fields[n]
+		n++
+		if expected.Name != prefix+field.Name || expected.Offset != offset+field.Offset ||
+			expected.Kind != field.Type.Kind() {
+			return n, false
+		}
+		if field.Type.Kind() == reflect.Struct && n < len(fields) &&
+			strings.HasPrefix(fields[n].Name, expected.Name+".") {
+			inner, ok := matchFields(field.Type, expected.Name+".", expected.Offset, fields[n:])
+			n += inner
+			if !ok {
+				return n, false
+			}
+		}
+	}
+	return n, true
+}
+
+// extract finds the children of the node with its registered extractor, returning false if there is none.
+func (c *childrenCollectorWalker) extract(node interface{}) (bool, error) {
+	e, ptr, ok := lookupChildrenExtractor(node)
+	if !ok {
+		return false, nil
+	}
+	if e.extract == nil {
+		return true, nil // No children
+	}
+	prefix := c.prefix
+	defer func() { c.prefix = prefix }()
+	for _, field := range e.extract(ptr) {
+		c.prefix = joinField(prefix, field.Name)
+		value := reflect.ValueOf(field.Pointer).Elem()
+		if (value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr) && value.IsNil() {
+			continue
+		}
+		if c.checkInterface(value.Interface(), nil, value) != nil {
+			continue // A child (or an ancestor)
+		}
+		// Not a child: explore it once, which may fall back to reflection
+		inner := value.Interface()
+		if id := identity(inner); id != nil {
+			if c.found[id] {
+				continue
+			}
+			c.found[id] = true
+		}
+		if ok, err := c.extract(inner); err != nil {
+			return true, err
+		} else if !ok {
+			if err = reflectwalktinygo.Walk(inner, c); err != nil {
+				return true, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// nodeTypeName returns the import path and name of the type of the node (or of the type it points to).
+func nodeTypeName(node interface{}) string {
+	t := reflect.TypeOf(node)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
@@ -4,45 +4,65 @@ package sdf_viewer_go_auto
 
 import (
 	"reflect"
-	"strconv"
 	"unsafe"
 )
 
-// Generic reflect implementation for the standard go compilers
+// Generic reflect implementation for the standard go compilers (native builds and GOOS=wasip1 with go:wasmexport)
 
 // interfaceAndImplementsHint returns the interface{} behind a reflect.Value, and may also return if it implements a
 // reflect.Type (of an interface). Optimization: it will only return the interface if the type cannot be checked or it was ok.
 func interfaceAndImplementsHint(value reflect.Value, kind reflect.Type) (interface{}, *bool) {
 	hint := false
 	if value.Type().Implements(kind) {
-		value = makeInterfaceWorkHack(value)
+		if value = makeInterfaceWorkHack(value); !value.IsValid() {
+			return nil, nil
+		}
 		return value.Interface(), &hint
 	}
 	return nil, nil
 }
 
+// makeInterfaceWorkHack returns a value that can be converted to an interface, or an invalid value if not possible.
 func makeInterfaceWorkHack(value reflect.Value) reflect.Value {
-	if !value.CanInterface() {
+	if value.CanInterface() {
+		return value
+	}
+	if value.CanAddr() {
 		// HACK: Read-only access to unexported value (Interface() is not allowed due to possible write operations?)
-		value = getUnexportedField(value, value.Addr().UnsafePointer())
+		return getUnexportedField(value, value.Addr().UnsafePointer())
+	}
+	// HACK: Unaddressable values of unexported fields (e.g. the values of maps) are rebuilt from their pointers
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		elem := makeInterfaceWorkHack(value.Elem())
+		if !elem.IsValid() {
+			return elem
+		}
+		res := reflect.New(value.Type()).Elem()
+		res.Set(elem)
+		return res
+	case reflect.Ptr:
+		return reflect.NewAt(value.Type().Elem(), value.UnsafePointer())
+	default:
+		return reflect.Value{}
 	}
-	return value
 }
 
-func getUnexportedField(field reflect.Value, unsafeAddr unsafe.Pointer) reflect.Value {
-	return reflect.NewAt(field.Type(), unsafeAddr).Elem()
+// settableValue returns a settable value for the memory of the value (even for unexported fields), or an invalid value
+// if it is not addressable.
+func settableValue(value reflect.Value) reflect.Value {
+	if value.CanSet() {
+		return value
+	}
+	if !value.CanAddr() {
+		return reflect.Value{}
+	}
+	return getUnexportedField(value, value.Addr().UnsafePointer())
 }
 
-var nameToNextIndex = map[string]int{}
-
-func nameOfType(kind reflect.Value, _ uintptr) string {
-	tp := kind.Type().String()
-	// Unique name of the type (avoid collisions)
-	if val, ok := nameToNextIndex[tp]; ok {
-		nameToNextIndex[tp] = val + 1
-		tp += "_" + strconv.Itoa(val)
-	} else {
-		nameToNextIndex[tp] = 1
-	}
-	return tp
+func getUnexportedField(field reflect.Value, unsafeAddr unsafe.Pointer) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafeAddr).Elem()
 }
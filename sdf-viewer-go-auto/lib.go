@@ -9,10 +9,10 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
-	"unsafe"
 )
 
 var _ sdfviewergo.SDF = &SDF{}
+var _ sdfviewergo.BatchSampler = &SDF{}
 
 type SDFCore interface {
 	SDFCoreEval([3]float32) float32
@@ -29,6 +29,9 @@ type SDF struct {
 	sdfCoreType reflect.Type
 	// Casting/conversion code to the SDFCore interface
 	castCoreType func(interface{}) (SDFCore, bool)
+	// PerturbCore returns a copy of a child, as found in the underlying library (e.g. its SDF3 interface), with all its
+	// distances offset by the given amount. It is only needed for PruneUnusedChildren.
+	PerturbCore func(child interface{}, offset float32) interface{}
 	// PARAMETERS TO CONFIGURE BY USER
 	// BoundingBoxCache is the cached bounding box of the SDF.
 	BoundingBoxCache *[2][3]float32
@@ -37,12 +40,19 @@ type SDF struct {
 	// property to it.
 	// If left as nil, it will use the default material function.
 	MaterialFunc func(point [3]float32, sample *sdfviewergo.SDFSample)
-	// NameCache is the name of this SDF object. If left as empty, it will use the default name.
+	// NameCache is the name of this SDF object. If left as empty, it will use the default name: the path from the root,
+	// with the field (or label) and the type of each node (e.g. "DifferenceSDF3/s0:UnionSDF3/sdf[2]:TransformSDF3"),
+	// which is the same on every run.
 	NameCache string
 	// ChildrenCache is the list of children of this SDF object.
 	// If left as empty (or manually set to nil), it will automatically the default children by exploring the SDF
 	// hierarchy using reflect. This is automatically set to nil after Changed is true.
 	ChildrenCache []sdfviewergo.SDF
+	// PruneUnusedChildren removes the automatic children that never influence the distances of this SDF (e.g. unused
+	// SDF3s that are only stored by the parent), by perturbing each of them (see PerturbCore). It is slower, and it is
+	// inherited by automatic children. The perturbed children are temporarily written into the structs of the underlying
+	// library, so the SDF must not be sampled while its children are computed.
+	PruneUnusedChildren bool
 	// ParametersList is the set of parameters to dynamically configure this SDF.
 	// Should be set manually as the default is no parameters.
 	ParametersList []sdfviewergo.SDFParam
@@ -58,6 +68,11 @@ type SDF struct {
 	BaseSample *sdfviewergo.SDFSample
 	// Noise is the noise generator used to generate noise for this SDF.
 	Noise opensimplex.Noise32
+
+	// ancestors are the identities of the ancestors of automatic children, which are ignored as their children.
+	ancestors []interface{}
+	// parentName and locator (the field path or label in the parent) form the default name of automatic children.
+	parentName, locator string
 }
 
 // NewSDF see SDF
@@ -91,12 +106,12 @@ func (s *SDF) Sample(point [3]float32, distanceOnly bool) (sample sdfviewergo.SD
 				sample.Distance = dist     // Recover distance
 			} else { // Non-leaf nodes (union, intersection, difference, etc...): copy closest child material
 				closest := math.MaxFloat64
-				var closestChild *SDF
+				var closestChild sdfviewergo.SDF
 				for _, child := range children {
 					childSample := child.Sample(point, true)
 					if math.Abs(float64(childSample.Distance)) <= closest { // <= seems to work better on ties, but it's a hack
 						closest = float64(childSample.Distance)
-						closestChild = child.(*SDF)
+						closestChild = child
 					}
 				}
 				savedParentDistance := sample.Distance
@@ -108,6 +123,69 @@ func (s *SDF) Sample(point [3]float32, distanceOnly bool) (sample sdfviewergo.SD
 	return
 }
 
+func (s *SDF) SampleBatch(points [][3]float32, distanceOnly bool, samples []sdfviewergo.SDFSample) {
+	for i, point := range points {
+		samples[i] = sdfviewergo.SDFSample{Distance: s.SDF.SDFCoreEval(point)}
+	}
+	if distanceOnly {
+		return
+	}
+	if s.MaterialFunc != nil {
+		for i, point := range points {
+			s.MaterialFunc(point, &samples[i]) // Modifies the sample pointer
+		}
+		return
+	}
+	// Default material function (same as Sample, but each child is only visited once for all points)
+	children := s.Children()
+	if len(children) == 0 { // Leaf nodes: pseudo-random color based on object name
+		baseSample := s.getBaseSample()
+		for i := range samples {
+			dist := samples[i].Distance
+			samples[i] = baseSample
+			samples[i].Distance = dist
+		}
+		return
+	}
+	// Non-leaf nodes (union, intersection, difference, etc...): copy closest child material
+	closest := make([]float64, len(points))
+	closestChild := make([]int, len(points))
+	for i := range closest {
+		closest[i] = math.MaxFloat64
+	}
+	childSamples := make([]sdfviewergo.SDFSample, len(points))
+	for childIndex, child := range children {
+		sdfviewergo.SampleBatch(child, points, true, childSamples)
+		for i, childSample := range childSamples {
+			if math.Abs(float64(childSample.Distance)) <= closest[i] { // <= seems to work better on ties, but it's a hack
+				closest[i] = float64(childSample.Distance)
+				closestChild[i] = childIndex
+			}
+		}
+	}
+	childPoints := make([][3]float32, 0, len(points))
+	childPointIndices := make([]int, 0, len(points))
+	for childIndex, child := range children {
+		childPoints = childPoints[:0]
+		childPointIndices = childPointIndices[:0]
+		for i, point := range points {
+			if closestChild[i] == childIndex {
+				childPoints = append(childPoints, point)
+				childPointIndices = append(childPointIndices, i)
+			}
+		}
+		if len(childPoints) == 0 {
+			continue
+		}
+		sdfviewergo.SampleBatch(child, childPoints, false, childSamples[:len(childPoints)])
+		for j, i := range childPointIndices {
+			savedParentDistance := samples[i].Distance
+			samples[i] = childSamples[j]
+			samples[i].Distance = savedParentDistance
+		}
+	}
+}
+
 func (s *SDF) getBaseSample() sdfviewergo.SDFSample {
 	if s.BaseSample == nil {
 		name := s.Name()
@@ -143,32 +221,65 @@ func (s *SDF) Children() []sdfviewergo.SDF {
 	// Any interface matching the basic SDF interface will be added to the list of children and stop recursion.
 	// If this advanced SDF struct is found, the same behavior will be applied, but with access to more advanced features.
 
-	// HACK: This will "fail" for SDF3s that store unused instances of other SDF3s, by showing them when they are not used.
+	// HACK: This will "fail" for SDF3s that store unused instances of other SDF3s, by showing them when they are not used
+	// (unless PruneUnusedChildren is set).
 	// WARNING: This is a slow operation (reflect is used), so it is cached. However, you may need to invalidate the cache manually.
-	// FIXME: This will crash for SDF3s that store pointers to themselves (or cyclic dependencies in general).
+	// Cyclic structures are supported: each pointer is walked once, children shared by several fields are only
+	// returned once, and references to this SDF or its ancestors are ignored (so that the hierarchy is a tree).
+	// Children listed by a ChildrenProvider are preferred, and nodes of the types with a registered ChildrenExtractor
+	// are not explored with reflection.
 	// You may implement this yourself to bypass the above hacks.
 
 	// Start walking the underlying SDF struct, and collecting children.
+	childrenRoot := s.SDF.SDFCoreChildrenRoot()
 	walker := &childrenCollectorWalker{
 		sdfCoreType:         s.sdfCoreType,
 		castCoreType:        s.castCoreType,
+		ancestors:           s.ancestors,
+		perturbCore:         s.PerturbCore,
+		pruneUnused:         s.PruneUnusedChildren,
+		parentName:          s.Name(),
 		children:            make([]sdfviewergo.SDF, 0, 5),
+		found:               map[interface{}]bool{},
+		childIndex:          map[interface{}]int{},
 		curDepthLevel:       0,
 		skipEntryUntilLevel: 0,
 	}
-	err := reflectwalktinygo.Walk(s.SDF.SDFCoreChildrenRoot(), walker)
+	if id := identity(childrenRoot); id != nil {
+		walker.ancestors = append(append([]interface{}{}, s.ancestors...), id)
+	}
+	for _, id := range walker.ancestors {
+		walker.found[id] = true
+	}
+	var err error
+	if provided, ok := s.providedChildren(); ok {
+		walker.provided(provided)
+	} else {
+		var extracted bool
+		if extracted, err = walker.extract(childrenRoot); err == nil && !extracted { // Without reflection, for known types
+			err = reflectwalktinygo.Walk(childrenRoot, walker)
+		}
+	}
 	if err != nil {
 		panic(err) // Shouldn't happen?
 	}
 
 	s.ChildrenCache = walker.children
+	if s.PruneUnusedChildren && s.PerturbCore != nil {
+		s.ChildrenCache = s.pruneUnusedChildren(walker.children, walker.locations)
+	}
 	return s.ChildrenCache
 }
 
 func (s *SDF) Name() string {
 	if s.NameCache == "" { // Auto compute name from type info of the actual implementation node
-		root := s.SDF.SDFCoreChildrenRoot()
-		s.NameCache = nameOfType(reflect.Indirect(reflect.ValueOf(root)), uintptr(unsafe.Pointer(&root)))
+		s.NameCache = typeName(s.SDF.SDFCoreChildrenRoot())
+		if s.locator != "" {
+			s.NameCache = s.locator + ":" + s.NameCache
+		}
+		if s.parentName != "" {
+			s.NameCache = s.parentName + "/" + s.NameCache
+		}
 	}
 	return s.NameCache
 }
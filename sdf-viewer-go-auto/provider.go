@@ -0,0 +1,99 @@
+package sdf_viewer_go_auto
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"reflect"
+	"strconv"
+)
+
+// LabeledChild is a child SDF with a human label of its role in the parent (e.g. "left", "right" or "cutter"), which
+// replaces the field name in its default name (see SDF.Name).
+type LabeledChild struct {
+	Label string
+	// Child is a value of the SDF interface of the library (converted to an SDF like automatic children), or an
+	// sdfviewergo.SDF, which is kept as is. The label only names SDFs of this package (or wrappers that embed them) that
+	// have no name yet, as any other sdfviewergo.SDF names itself.
+	Child interface{}
+}
+
+// ChildrenProvider may be implemented by core SDFs (or by the nodes returned by SDFCoreChildrenRoot) that know their
+// own structure, to list their children instead of exploring them with reflection.
+type ChildrenProvider interface {
+	SDFChildren() []LabeledChild
+}
+
+var childrenProviders = map[string]func(node interface{}) []LabeledChild{}
+
+// RegisterChildrenProvider registers a function that lists the children of the nodes of a type (by import path and
+// name, e.g. "github.com/deadsy/sdfx/sdf.DifferenceSDF3"), like ChildrenProvider, for types that can't implement it.
+func RegisterChildrenProvider(typeName string, provide func(node interface{}) []LabeledChild) {
+	childrenProviders[typeName] = provide
+}
+
+// providedChildren returns the children listed by the core SDF, its node or a registered function, if any.
+func (s *SDF) providedChildren() ([]LabeledChild, bool) {
+	if p, ok := s.SDF.(ChildrenProvider); ok {
+		return p.SDFChildren(), true
+	}
+	root := s.SDF.SDFCoreChildrenRoot()
+	if p, ok := root.(ChildrenProvider); ok {
+		return p.SDFChildren(), true
+	}
+	if provide, ok := childrenProviders[nodeTypeName(root)]; ok {
+		return provide(root), true
+	}
+	return nil, false
+}
+
+// provided adds the provided children, which are only checked for duplicates and ancestors.
+func (c *childrenCollectorWalker) provided(children []LabeledChild) {
+	for i, child := range children {
+		c.prefix = child.Label
+		if c.prefix == "" {
+			c.prefix = "[" + strconv.Itoa(i) + "]"
+		}
+		if s, ok := child.Child.(sdfviewergo.SDF); ok {
+			c.providedSDF(s)
+		} else {
+			_ = c.checkInterface(child.Child, nil, reflect.Value{})
+		}
+	}
+	c.prefix = ""
+}
+
+// providedSDF adds a provided child that is already an sdfviewergo.SDF, unless it was already found or it is an
+// ancestor (by the node of the library that it wraps, if known).
+func (c *childrenCollectorWalker) providedSDF(s sdfviewergo.SDF) {
+	id := identity(s)
+	w, wrapped := s.(wrapper)
+	if wrapped {
+		if rootID := identity(w.auto().SDF.SDFCoreChildrenRoot()); rootID != nil {
+			id = rootID
+		}
+	}
+	if id != nil {
+		if c.found[id] {
+			return
+		}
+		c.found[id] = true
+		c.childIndex[id] = len(c.children)
+	}
+	if wrapped && w.auto().NameCache == "" && w.auto().parentName == "" {
+		child := w.auto()
+		child.parentName = c.parentName
+		child.locator = c.locator()
+		if child.ancestors == nil {
+			child.ancestors = c.ancestors
+		}
+	}
+	c.foundChild(s, reflect.Value{})
+}
+
+// wrapper is implemented by the SDFs of this package, and by the wrappers that embed them.
+type wrapper interface {
+	auto() *SDF
+}
+
+func (s *SDF) auto() *SDF {
+	return s
+}
@@ -0,0 +1,120 @@
+package sdf_viewer_go_auto
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// pruneSamplesPerAxis is the number of (jittered) points along each axis of the bounding box of each child where its
+// influence is checked.
+const pruneSamplesPerAxis = 8
+
+// pruneUnusedChildren returns the children that influence the distances of this SDF: each child is replaced at all
+// the locations where it was found by copies offset in both directions (see PerturbCore), and it is kept if any
+// distance of this SDF changes inside the bounding box of the child. Children that can't be replaced are always kept.
+//
+// The perturbed copies are written into the structs of the underlying library while checking, so this SDF must not be
+// sampled concurrently.
+func (s *SDF) pruneUnusedChildren(children []sdfviewergo.SDF, locations [][]reflect.Value) []sdfviewergo.SDF {
+	parentAABB := s.SDF.SDFCoreAABB()
+	res := make([]sdfviewergo.SDF, 0, len(children))
+	for i, child := range children {
+		aabb := child.AABB()
+		if !finiteAABB(aabb) {
+			aabb = parentAABB
+		}
+		offset := 0.05 * longestSide(aabb)
+		if offset <= 0 {
+			offset = 0.05 * longestSide(parentAABB)
+		}
+		if offset <= 0 || math.IsNaN(float64(offset)) || math.IsInf(float64(offset), 0) {
+			offset = 1
+		}
+		for j := 0; j < 3; j++ { // The perturbed child may influence the distances a bit further away
+			aabb[0][j] -= offset
+			aabb[1][j] += offset
+		}
+		points := prunePoints(aabb)
+		expected := make([]float32, len(points))
+		for j, p := range points {
+			expected[j] = s.SDF.SDFCoreEval(p)
+		}
+		if s.childUsed(locations[i], offset, points, expected) {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
+// prunePoints returns the points where the influence of a child is checked, inside the given bounding box.
+func prunePoints(aabb [2][3]float32) [][3]float32 {
+	rng := rand.New(rand.NewSource(1)) // Deterministic points, so that the tree is the same on every run
+	points := make([][3]float32, 0, pruneSamplesPerAxis*pruneSamplesPerAxis*pruneSamplesPerAxis)
+	for x := 0; x < pruneSamplesPerAxis; x++ {
+		for y := 0; y < pruneSamplesPerAxis; y++ {
+			for z := 0; z < pruneSamplesPerAxis; z++ {
+				var p [3]float32
+				for i, cell := range [3]int{x, y, z} {
+					t := (float32(cell) + rng.Float32()) / pruneSamplesPerAxis
+					p[i] = aabb[0][i] + t*(aabb[1][i]-aabb[0][i])
+				}
+				points = append(points, p)
+			}
+		}
+	}
+	return points
+}
+
+func longestSide(aabb [2][3]float32) float32 {
+	longest := float32(0)
+	for i := 0; i < 3; i++ {
+		longest = float32(math.Max(float64(longest), float64(aabb[1][i]-aabb[0][i])))
+	}
+	return longest
+}
+
+func finiteAABB(aabb [2][3]float32) bool {
+	for _, corner := range aabb {
+		for _, v := range corner {
+			if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+				return false
+			}
+		}
+	}
+	return aabb[0][0] <= aabb[1][0] && aabb[0][1] <= aabb[1][1] && aabb[0][2] <= aabb[1][2]
+}
+
+// childUsed perturbs the child at the given locations and checks whether the distances at the points change.
+func (s *SDF) childUsed(locations []reflect.Value, offset float32, points [][3]float32, expected []float32) bool {
+	originals := make([]reflect.Value, len(locations))
+	for i, location := range locations {
+		if !location.IsValid() {
+			return true // Unknown location, so it can't be perturbed
+		}
+		originals[i] = reflect.New(location.Type()).Elem()
+		originals[i].Set(location)
+	}
+	defer func() { // Always restore the original children
+		for i, location := range locations {
+			location.Set(originals[i])
+		}
+	}()
+	tolerance := 1e-3 * float64(offset)
+	for _, sign := range []float32{1, -1} { // Both directions, as e.g. unions only use the minimum distances
+		for i, location := range locations {
+			perturbed := reflect.ValueOf(s.PerturbCore(originals[i].Interface(), sign*offset))
+			if !perturbed.IsValid() || !perturbed.Type().AssignableTo(location.Type()) {
+				return true // Can't perturb this child
+			}
+			location.Set(perturbed)
+		}
+		for i, p := range points {
+			if math.Abs(float64(s.SDF.SDFCoreEval(p)-expected[i])) > tolerance {
+				return true
+			}
+		}
+	}
+	return false
+}
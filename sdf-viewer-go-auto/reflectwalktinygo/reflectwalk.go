@@ -1,6 +1,8 @@
 // Package reflectwalktinygo is a copy of https://github.com/mitchellh/reflectwalk/
 // (v1.0.2) adapted to work with tinygo builds.
-// The patch applied is only `FieldByIndex([]int{i})` --> `Field(i)`.
+// The patches applied are `FieldByIndex([]int{i})` --> `Field(i)`, SkipEntry support for InterfaceWalker, the
+// tracking of visited pointers, maps and slices (see RevisitWalker), so that cyclic structures can be walked, and the
+// sorting of map keys, so that walks are deterministic.
 //
 // reflectwalk is a package that allows you to "walk" complex structures
 // similar to how you may "walk" a filesystem: visiting every element one
@@ -11,6 +13,7 @@ package reflectwalktinygo
 import (
 	"errors"
 	"reflect"
+	"sort"
 )
 
 type Location uint
@@ -96,11 +99,20 @@ type PointerValueWalker interface {
 	Pointer(reflect.Value) error
 }
 
+// RevisitWalker implementations are notified when a pointer, map or slice that was already walked is found again,
+// instead of walking it again. cycle is true if it is being walked (it contains itself), and false if it is shared
+// by different parts of the structure.
+type RevisitWalker interface {
+	Revisit(v reflect.Value, cycle bool) error
+}
+
 // SkipEntry can be returned from walk functions to skip walking
 // the value of this field. This is only valid in the following functions:
 //
 //   - Struct: skips all fields from being walked
 //   - StructField: skips walking the struct value
+//   - Interface: skips walking the value of the interface
+//   - Pointer: skips walking the value of the pointer
 var SkipEntry = errors.New("skip this entry")
 
 // Walk takes an arbitrary value and an interface and traverses the
@@ -115,7 +127,7 @@ func Walk(data, walker interface{}) (err error) {
 	}
 
 	if err == nil {
-		err = walk(v, walker)
+		err = walk(v, walker, newWalkState(walker))
 	}
 
 	if ok && err == nil {
@@ -125,7 +137,51 @@ func Walk(data, walker interface{}) (err error) {
 	return
 }
 
-func walk(v reflect.Value, w interface{}) (err error) {
+// walkState tracks the pointers, maps and slices that were visited during a Walk.
+type walkState struct {
+	w       interface{}
+	visited map[visitKey]bool // All the visited values, to true if they are being walked
+}
+
+// visitKey identifies the memory behind a pointer, map or slice.
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int // Of slices, as several slices may share the same array
+}
+
+func newWalkState(w interface{}) *walkState {
+	return &walkState{w: w, visited: map[visitKey]bool{}}
+}
+
+// enter records that the pointer, map or slice is being walked, and returns true if it was already visited (notifying
+// the walker), in which case it must be skipped.
+func (st *walkState) enter(v reflect.Value) (visitKey, bool, error) {
+	k := visitKey{typ: v.Type(), ptr: v.Pointer()}
+	if v.Kind() == reflect.Slice {
+		k.len = v.Len()
+	}
+	if k.ptr == 0 {
+		return k, false, nil // Nil or empty: nothing to walk twice
+	}
+	if walking, ok := st.visited[k]; ok {
+		if rw, ok := st.w.(RevisitWalker); ok {
+			return k, true, rw.Revisit(v, walking)
+		}
+		return k, true, nil
+	}
+	st.visited[k] = true
+	return k, false, nil
+}
+
+// exit records that the pointer, map or slice is no longer being walked.
+func (st *walkState) exit(k visitKey) {
+	if k.ptr != 0 {
+		st.visited[k] = false
+	}
+}
+
+func walk(v reflect.Value, w interface{}, st *walkState) (err error) {
 	// Determine if we're receiving a pointer and if so notify the walker.
 	// The logic here is convoluted but very important (tests will fail if
 	// almost any part is changed). I will try to explain here.
@@ -148,6 +204,11 @@ func walk(v reflect.Value, w interface{}) (err error) {
 		if pointerV.Kind() == reflect.Interface {
 			if iw, ok := w.(InterfaceWalker); ok {
 				if err = iw.Interface(pointerV); err != nil {
+					if err == SkipEntry {
+						// Skip the rest of this entry but clear the error
+						return nil
+					}
+
 					return
 				}
 			}
@@ -167,6 +228,13 @@ func walk(v reflect.Value, w interface{}) (err error) {
 				}
 			}
 
+			var key visitKey
+			var revisited bool
+			if key, revisited, err = st.enter(pointerV); revisited {
+				return
+			}
+			defer st.exit(key)
+
 			pointer = true
 			v = reflect.Indirect(pointerV)
 		}
@@ -215,24 +283,28 @@ func walk(v reflect.Value, w interface{}) (err error) {
 	case reflect.Bool, reflect.Chan, reflect.Func, reflect.Int, reflect.String, reflect.Invalid:
 		err = walkPrimitive(originalV, w)
 		return
-	case reflect.Map:
-		err = walkMap(v, w)
-		return
-	case reflect.Slice:
-		err = walkSlice(v, w)
-		return
+	case reflect.Map, reflect.Slice:
+		key, revisited, err := st.enter(v)
+		if revisited {
+			return err
+		}
+		defer st.exit(key)
+		if k == reflect.Map {
+			return walkMap(v, w, st)
+		}
+		return walkSlice(v, w, st)
 	case reflect.Struct:
-		err = walkStruct(v, w)
+		err = walkStruct(v, w, st)
 		return
 	case reflect.Array:
-		err = walkArray(v, w)
+		err = walkArray(v, w, st)
 		return
 	default:
 		panic("unsupported type: " + k.String())
 	}
 }
 
-func walkMap(v reflect.Value, w interface{}) error {
+func walkMap(v reflect.Value, w interface{}, st *walkState) error {
 	ew, ewok := w.(EnterExitWalker)
 	if ewok {
 		ew.Enter(Map)
@@ -244,7 +316,9 @@ func walkMap(v reflect.Value, w interface{}) error {
 		}
 	}
 
-	for _, k := range v.MapKeys() {
+	keys := v.MapKeys()
+	sortMapKeys(keys) // Deterministic order, as the iteration order of maps is random
+	for _, k := range keys {
 		kv := v.MapIndex(k)
 
 		if mw, ok := w.(MapWalker); ok {
@@ -258,7 +332,7 @@ func walkMap(v reflect.Value, w interface{}) error {
 			ew.Enter(MapKey)
 		}
 
-		if err := walk(k, w); err != nil {
+		if err := walk(k, w, st); err != nil {
 			return err
 		}
 
@@ -268,7 +342,7 @@ func walkMap(v reflect.Value, w interface{}) error {
 		}
 
 		// get the map value again as it may have changed in the MapElem call
-		if err := walk(v.MapIndex(k), w); err != nil {
+		if err := walk(v.MapIndex(k), w, st); err != nil {
 			return err
 		}
 
@@ -284,6 +358,40 @@ func walkMap(v reflect.Value, w interface{}) error {
 	return nil
 }
 
+// sortMapKeys sorts the keys of a map by value, for basic kinds (and interfaces holding them, by kind first). The order
+// of any other keys is unchanged.
+func sortMapKeys(keys []reflect.Value) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		return lessMapKey(keys[i], keys[j])
+	})
+}
+
+func lessMapKey(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface && b.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() && !b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+	if a.Kind() != b.Kind() {
+		return a.Kind() < b.Kind()
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return false
+	}
+}
+
 func walkPrimitive(v reflect.Value, w interface{}) error {
 	if pw, ok := w.(PrimitiveWalker); ok {
 		return pw.Primitive(v)
@@ -292,7 +400,7 @@ func walkPrimitive(v reflect.Value, w interface{}) error {
 	return nil
 }
 
-func walkSlice(v reflect.Value, w interface{}) (err error) {
+func walkSlice(v reflect.Value, w interface{}, st *walkState) (err error) {
 	ew, ok := w.(EnterExitWalker)
 	if ok {
 		ew.Enter(Slice)
@@ -318,7 +426,7 @@ func walkSlice(v reflect.Value, w interface{}) (err error) {
 			ew.Enter(SliceElem)
 		}
 
-		if err := walk(elem, w); err != nil {
+		if err := walk(elem, w, st); err != nil {
 			return err
 		}
 
@@ -335,7 +443,7 @@ func walkSlice(v reflect.Value, w interface{}) (err error) {
 	return nil
 }
 
-func walkArray(v reflect.Value, w interface{}) (err error) {
+func walkArray(v reflect.Value, w interface{}, st *walkState) (err error) {
 	ew, ok := w.(EnterExitWalker)
 	if ok {
 		ew.Enter(Array)
@@ -361,7 +469,7 @@ func walkArray(v reflect.Value, w interface{}) (err error) {
 			ew.Enter(ArrayElem)
 		}
 
-		if err := walk(elem, w); err != nil {
+		if err := walk(elem, w, st); err != nil {
 			return err
 		}
 
@@ -378,7 +486,7 @@ func walkArray(v reflect.Value, w interface{}) (err error) {
 	return nil
 }
 
-func walkStruct(v reflect.Value, w interface{}) (err error) {
+func walkStruct(v reflect.Value, w interface{}, st *walkState) (err error) {
 	ew, ewok := w.(EnterExitWalker)
 	if ewok {
 		ew.Enter(Struct)
@@ -420,7 +528,7 @@ func walkStruct(v reflect.Value, w interface{}) (err error) {
 				ew.Enter(StructField)
 			}
 
-			err = walk(f, w)
+			err = walk(f, w, st)
 			if err != nil {
 				return
 			}
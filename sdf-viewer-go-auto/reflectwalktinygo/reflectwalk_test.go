@@ -0,0 +1,56 @@
+package reflectwalktinygo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// revisitCounter counts the strings and revisits of a walk.
+type revisitCounter struct {
+	strings, cycles, shared int
+}
+
+func (r *revisitCounter) Primitive(v reflect.Value) error {
+	if v.Kind() == reflect.String {
+		r.strings++
+	}
+	return nil
+}
+
+func (r *revisitCounter) Revisit(_ reflect.Value, cycle bool) error {
+	if cycle {
+		r.cycles++
+	} else {
+		r.shared++
+	}
+	return nil
+}
+
+type linked struct {
+	name string
+	next *linked
+	refs []*linked
+}
+
+func TestWalkRevisit(t *testing.T) {
+	a := &linked{name: "a"}
+	b := &linked{name: "b", next: a}
+	a.next = b // a -> b -> a
+	shared := &linked{name: "shared"}
+	a.refs = []*linked{shared, shared}
+	m := map[string]interface{}{"a": a}
+	m["self"] = m
+
+	var counter revisitCounter
+	if err := Walk(m, &counter); err != nil {
+		t.Fatal(err)
+	}
+	// The keys of the map ("a" and "self") and the names of the 3 structs are walked once
+	if counter.strings != 5 {
+		t.Fatalf("expected 5 strings, got %d", counter.strings)
+	}
+	// a from b and m from itself are cycles, the second shared is not
+	if counter.cycles != 2 || counter.shared != 1 {
+		t.Fatalf("expected 2 cycles and 1 shared value, got %d and %d", counter.cycles, counter.shared)
+	}
+}
@@ -4,12 +4,12 @@ package sdf_viewer_go_auto
 
 import (
 	"reflect"
-	"strconv"
 	"unsafe"
 )
 
 // Reflection hacks specific to the tinygo compiler, as it does not support some features of the go standard library.
 // HACK: TinyGo has very limited support for reflect (e.g. no Implements() or Interface()), which we must work around.
+// These hacks are not needed for the types with a registered ChildrenExtractor (see the childrengen package).
 
 // HACK: Internal type
 type Value struct {
@@ -35,6 +35,11 @@ func interfaceAndImplementsHint(value reflect.Value, kind reflect.Type) (interfa
 	return hackedValueIface, nil /* no way to know the hint */
 }
 
-func nameOfType(val reflect.Value, ptr uintptr) string {
-	return val.Type().String() + "(" + strconv.Itoa(int(ptr)) + ")"
+// settableValue returns a settable value for the memory of the value, or an invalid value if it is not possible.
+// HACK: Unexported fields can't be set with TinyGo, so children are never pruned (see SDF.PruneUnusedChildren).
+func settableValue(value reflect.Value) reflect.Value {
+	if value.CanSet() {
+		return value
+	}
+	return reflect.Value{}
 }
@@ -0,0 +1,632 @@
+// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.
+
+package sdf_viewer_go_auto
+
+import (
+	sdfviewergoauto "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
+	"github.com/soypat/sdf"
+	"gonum.org/v1/gonum/spatial/r2"
+	"gonum.org/v1/gonum/spatial/r3"
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+func init() {
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.array3", unsafe.Sizeof(layoutArray3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutArray3{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutArray3{}.num), Kind: reflect.Array},
+		{Name: "step", Offset: unsafe.Offsetof(layoutArray3{}.step), Kind: reflect.Struct},
+		{Name: "min", Offset: unsafe.Offsetof(layoutArray3{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutArray3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutArray3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.cut3", unsafe.Sizeof(layoutCut3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutCut3{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutCut3{}.a), Kind: reflect.Struct},
+		{Name: "n", Offset: unsafe.Offsetof(layoutCut3{}.n), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCut3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutCut3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.diff3", unsafe.Sizeof(layoutDiff3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutDiff3{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutDiff3{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutDiff3{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutDiff3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutDiff3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.elongate3", unsafe.Sizeof(layoutElongate3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutElongate3{}.sdf), Kind: reflect.Interface},
+		{Name: "hp", Offset: unsafe.Offsetof(layoutElongate3{}.hp), Kind: reflect.Struct},
+		{Name: "hn", Offset: unsafe.Offsetof(layoutElongate3{}.hn), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutElongate3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutElongate3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.extrude3", unsafe.Sizeof(layoutExtrude3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutExtrude3{}.sdf), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutExtrude3{}.height), Kind: reflect.Float64},
+		{Name: "extrude", Offset: unsafe.Offsetof(layoutExtrude3{}.extrude), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutExtrude3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutExtrude3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.extrudeRounded", unsafe.Sizeof(layoutExtrudeRounded{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutExtrudeRounded{}.sdf), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutExtrudeRounded{}.height), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutExtrudeRounded{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutExtrudeRounded{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutExtrudeRounded)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.intersection3", unsafe.Sizeof(layoutIntersection3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutIntersection3{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutIntersection3{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutIntersection3{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutIntersection3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutIntersection3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.loft3", unsafe.Sizeof(layoutLoft3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf0", Offset: unsafe.Offsetof(layoutLoft3{}.sdf0), Kind: reflect.Interface},
+		{Name: "sdf1", Offset: unsafe.Offsetof(layoutLoft3{}.sdf1), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutLoft3{}.height), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutLoft3{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutLoft3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutLoft3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf0", Pointer: &n.sdf0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf1", Pointer: &n.sdf1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.offset3", unsafe.Sizeof(layoutOffset3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutOffset3{}.sdf), Kind: reflect.Interface},
+		{Name: "distance", Offset: unsafe.Offsetof(layoutOffset3{}.distance), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutOffset3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutOffset3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.revolution3", unsafe.Sizeof(layoutRevolution3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRevolution3{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutRevolution3{}.theta), Kind: reflect.Float64},
+		{Name: "norm", Offset: unsafe.Offsetof(layoutRevolution3{}.norm), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRevolution3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRevolution3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.rotateCopy3", unsafe.Sizeof(layoutRotateCopy3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateCopy3{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutRotateCopy3{}.theta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateCopy3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateCopy3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.rotateUnion", unsafe.Sizeof(layoutRotateUnion{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateUnion{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutRotateUnion{}.num), Kind: reflect.Int},
+		{Name: "step", Offset: unsafe.Offsetof(layoutRotateUnion{}.step), Kind: reflect.Struct},
+		{Name: "step.x00", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x00), Kind: reflect.Float64},
+		{Name: "step.x01", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x01), Kind: reflect.Float64},
+		{Name: "step.x02", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x02), Kind: reflect.Float64},
+		{Name: "step.x03", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x03), Kind: reflect.Float64},
+		{Name: "step.x10", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x10), Kind: reflect.Float64},
+		{Name: "step.x11", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x11), Kind: reflect.Float64},
+		{Name: "step.x12", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x12), Kind: reflect.Float64},
+		{Name: "step.x13", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x13), Kind: reflect.Float64},
+		{Name: "step.x20", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x20), Kind: reflect.Float64},
+		{Name: "step.x21", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x21), Kind: reflect.Float64},
+		{Name: "step.x22", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x22), Kind: reflect.Float64},
+		{Name: "step.x23", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x23), Kind: reflect.Float64},
+		{Name: "step.x30", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x30), Kind: reflect.Float64},
+		{Name: "step.x31", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x31), Kind: reflect.Float64},
+		{Name: "step.x32", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x32), Kind: reflect.Float64},
+		{Name: "step.x33", Offset: unsafe.Offsetof(layoutRotateUnion{}.step) + unsafe.Offsetof(layoutRotateUnion{}.step.x33), Kind: reflect.Float64},
+		{Name: "min", Offset: unsafe.Offsetof(layoutRotateUnion{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateUnion{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateUnion)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.scaleUniform3", unsafe.Sizeof(layoutScaleUniform3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutScaleUniform3{}.sdf), Kind: reflect.Interface},
+		{Name: "k", Offset: unsafe.Offsetof(layoutScaleUniform3{}.k), Kind: reflect.Float64},
+		{Name: "invK", Offset: unsafe.Offsetof(layoutScaleUniform3{}.invK), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutScaleUniform3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutScaleUniform3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.shell3", unsafe.Sizeof(layoutShell3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutShell3{}.sdf), Kind: reflect.Interface},
+		{Name: "delta", Offset: unsafe.Offsetof(layoutShell3{}.delta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutShell3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutShell3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.transform3", unsafe.Sizeof(layoutTransform3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutTransform3{}.sdf), Kind: reflect.Interface},
+		{Name: "matrix", Offset: unsafe.Offsetof(layoutTransform3{}.matrix), Kind: reflect.Struct},
+		{Name: "matrix.x00", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x00), Kind: reflect.Float64},
+		{Name: "matrix.x01", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x01), Kind: reflect.Float64},
+		{Name: "matrix.x02", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x02), Kind: reflect.Float64},
+		{Name: "matrix.x03", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x03), Kind: reflect.Float64},
+		{Name: "matrix.x10", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x10), Kind: reflect.Float64},
+		{Name: "matrix.x11", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x11), Kind: reflect.Float64},
+		{Name: "matrix.x12", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x12), Kind: reflect.Float64},
+		{Name: "matrix.x13", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x13), Kind: reflect.Float64},
+		{Name: "matrix.x20", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x20), Kind: reflect.Float64},
+		{Name: "matrix.x21", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x21), Kind: reflect.Float64},
+		{Name: "matrix.x22", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x22), Kind: reflect.Float64},
+		{Name: "matrix.x23", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x23), Kind: reflect.Float64},
+		{Name: "matrix.x30", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x30), Kind: reflect.Float64},
+		{Name: "matrix.x31", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x31), Kind: reflect.Float64},
+		{Name: "matrix.x32", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x32), Kind: reflect.Float64},
+		{Name: "matrix.x33", Offset: unsafe.Offsetof(layoutTransform3{}.matrix) + unsafe.Offsetof(layoutTransform3{}.matrix.x33), Kind: reflect.Float64},
+		{Name: "inverse", Offset: unsafe.Offsetof(layoutTransform3{}.inverse), Kind: reflect.Struct},
+		{Name: "inverse.x00", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x00), Kind: reflect.Float64},
+		{Name: "inverse.x01", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x01), Kind: reflect.Float64},
+		{Name: "inverse.x02", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x02), Kind: reflect.Float64},
+		{Name: "inverse.x03", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x03), Kind: reflect.Float64},
+		{Name: "inverse.x10", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x10), Kind: reflect.Float64},
+		{Name: "inverse.x11", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x11), Kind: reflect.Float64},
+		{Name: "inverse.x12", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x12), Kind: reflect.Float64},
+		{Name: "inverse.x13", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x13), Kind: reflect.Float64},
+		{Name: "inverse.x20", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x20), Kind: reflect.Float64},
+		{Name: "inverse.x21", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x21), Kind: reflect.Float64},
+		{Name: "inverse.x22", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x22), Kind: reflect.Float64},
+		{Name: "inverse.x23", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x23), Kind: reflect.Float64},
+		{Name: "inverse.x30", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x30), Kind: reflect.Float64},
+		{Name: "inverse.x31", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x31), Kind: reflect.Float64},
+		{Name: "inverse.x32", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x32), Kind: reflect.Float64},
+		{Name: "inverse.x33", Offset: unsafe.Offsetof(layoutTransform3{}.inverse) + unsafe.Offsetof(layoutTransform3{}.inverse.x33), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutTransform3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutTransform3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.union3", unsafe.Sizeof(layoutUnion3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutUnion3{}.sdf), Kind: reflect.Slice},
+		{Name: "min", Offset: unsafe.Offsetof(layoutUnion3{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutUnion3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutUnion3)(node)
+		for i2 := range n.sdf {
+			res = append(res, sdfviewergoauto.NodeField{Name: "sdf[" + strconv.Itoa(i2) + "]", Pointer: &n.sdf[i2]})
+		}
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.CutSDF2", unsafe.Sizeof(layoutCutSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutCutSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutCutSDF2{}.a), Kind: reflect.Struct},
+		{Name: "n", Offset: unsafe.Offsetof(layoutCutSDF2{}.n), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCutSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutCutSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.ScaleUniformSDF2", unsafe.Sizeof(layoutScaleUniformSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "k", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.k), Kind: reflect.Float64},
+		{Name: "invk", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.invk), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutScaleUniformSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.TransformSDF2", unsafe.Sizeof(layoutTransformSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutTransformSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "mInv", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv), Kind: reflect.Struct},
+		{Name: "mInv.x00", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x00), Kind: reflect.Float64},
+		{Name: "mInv.x01", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x01), Kind: reflect.Float64},
+		{Name: "mInv.x02", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x02), Kind: reflect.Float64},
+		{Name: "mInv.x10", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x10), Kind: reflect.Float64},
+		{Name: "mInv.x11", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x11), Kind: reflect.Float64},
+		{Name: "mInv.x12", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x12), Kind: reflect.Float64},
+		{Name: "mInv.x20", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x20), Kind: reflect.Float64},
+		{Name: "mInv.x21", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x21), Kind: reflect.Float64},
+		{Name: "mInv.x22", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv) + unsafe.Offsetof(layoutTransformSDF2{}.mInv.x22), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutTransformSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutTransformSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.array2", unsafe.Sizeof(layoutArray2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutArray2{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutArray2{}.num), Kind: reflect.Array},
+		{Name: "step", Offset: unsafe.Offsetof(layoutArray2{}.step), Kind: reflect.Struct},
+		{Name: "min", Offset: unsafe.Offsetof(layoutArray2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutArray2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutArray2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.diff2", unsafe.Sizeof(layoutDiff2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutDiff2{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutDiff2{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutDiff2{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutDiff2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutDiff2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.elongate2", unsafe.Sizeof(layoutElongate2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutElongate2{}.sdf), Kind: reflect.Interface},
+		{Name: "hp", Offset: unsafe.Offsetof(layoutElongate2{}.hp), Kind: reflect.Struct},
+		{Name: "hn", Offset: unsafe.Offsetof(layoutElongate2{}.hn), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutElongate2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutElongate2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.empty2", unsafe.Sizeof(layoutEmpty2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "center", Offset: unsafe.Offsetof(layoutEmpty2{}.center), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.intersection2", unsafe.Sizeof(layoutIntersection2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutIntersection2{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutIntersection2{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutIntersection2{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutIntersection2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutIntersection2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.offset2", unsafe.Sizeof(layoutOffset2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutOffset2{}.sdf), Kind: reflect.Interface},
+		{Name: "offset", Offset: unsafe.Offsetof(layoutOffset2{}.offset), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutOffset2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutOffset2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.rotateCopy2", unsafe.Sizeof(layoutRotateCopy2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateCopy2{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutRotateCopy2{}.theta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateCopy2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateCopy2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.rotateUnion2", unsafe.Sizeof(layoutRotateUnion2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateUnion2{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutRotateUnion2{}.num), Kind: reflect.Int},
+		{Name: "step", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step), Kind: reflect.Struct},
+		{Name: "step.x00", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x00), Kind: reflect.Float64},
+		{Name: "step.x01", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x01), Kind: reflect.Float64},
+		{Name: "step.x02", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x02), Kind: reflect.Float64},
+		{Name: "step.x10", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x10), Kind: reflect.Float64},
+		{Name: "step.x11", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x11), Kind: reflect.Float64},
+		{Name: "step.x12", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x12), Kind: reflect.Float64},
+		{Name: "step.x20", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x20), Kind: reflect.Float64},
+		{Name: "step.x21", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x21), Kind: reflect.Float64},
+		{Name: "step.x22", Offset: unsafe.Offsetof(layoutRotateUnion2{}.step) + unsafe.Offsetof(layoutRotateUnion2{}.step.x22), Kind: reflect.Float64},
+		{Name: "min", Offset: unsafe.Offsetof(layoutRotateUnion2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateUnion2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateUnion2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.slice2", unsafe.Sizeof(layoutSlice2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutSlice2{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutSlice2{}.a), Kind: reflect.Struct},
+		{Name: "u", Offset: unsafe.Offsetof(layoutSlice2{}.u), Kind: reflect.Struct},
+		{Name: "v", Offset: unsafe.Offsetof(layoutSlice2{}.v), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutSlice2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutSlice2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/soypat/sdf.union2", unsafe.Sizeof(layoutUnion2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutUnion2{}.sdf), Kind: reflect.Slice},
+		{Name: "min", Offset: unsafe.Offsetof(layoutUnion2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutUnion2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutUnion2)(node)
+		for i2 := range n.sdf {
+			res = append(res, sdfviewergoauto.NodeField{Name: "sdf[" + strconv.Itoa(i2) + "]", Pointer: &n.sdf[i2]})
+		}
+		return res
+	})
+}
+
+// layoutArray3 has the memory layout of sdf.array3.
+type layoutArray3 struct {
+	sdf  sdf.SDF3
+	num  sdf.V3i
+	step r3.Vec
+	min  sdf.MinFunc
+	bb   r3.Box
+}
+
+// layoutCut3 has the memory layout of sdf.cut3.
+type layoutCut3 struct {
+	sdf sdf.SDF3
+	a   r3.Vec
+	n   r3.Vec
+	bb  r3.Box
+}
+
+// layoutDiff3 has the memory layout of sdf.diff3.
+type layoutDiff3 struct {
+	s0  sdf.SDF3
+	s1  sdf.SDF3
+	max sdf.MaxFunc
+	bb  r3.Box
+}
+
+// layoutElongate3 has the memory layout of sdf.elongate3.
+type layoutElongate3 struct {
+	sdf sdf.SDF3
+	hp  r3.Vec
+	hn  r3.Vec
+	bb  r3.Box
+}
+
+// layoutExtrude3 has the memory layout of sdf.extrude3.
+type layoutExtrude3 struct {
+	sdf     sdf.SDF2
+	height  float64
+	extrude sdf.ExtrudeFunc
+	bb      r3.Box
+}
+
+// layoutExtrudeRounded has the memory layout of sdf.extrudeRounded.
+type layoutExtrudeRounded struct {
+	sdf    sdf.SDF2
+	height float64
+	round  float64
+	bb     r3.Box
+}
+
+// layoutIntersection3 has the memory layout of sdf.intersection3.
+type layoutIntersection3 struct {
+	s0  sdf.SDF3
+	s1  sdf.SDF3
+	max sdf.MaxFunc
+	bb  r3.Box
+}
+
+// layoutLoft3 has the memory layout of sdf.loft3.
+type layoutLoft3 struct {
+	sdf0   sdf.SDF2
+	sdf1   sdf.SDF2
+	height float64
+	round  float64
+	bb     r3.Box
+}
+
+// layoutOffset3 has the memory layout of sdf.offset3.
+type layoutOffset3 struct {
+	sdf      sdf.SDF3
+	distance float64
+	bb       r3.Box
+}
+
+// layoutRevolution3 has the memory layout of sdf.revolution3.
+type layoutRevolution3 struct {
+	sdf   sdf.SDF2
+	theta float64
+	norm  r2.Vec
+	bb    r3.Box
+}
+
+// layoutRotateCopy3 has the memory layout of sdf.rotateCopy3.
+type layoutRotateCopy3 struct {
+	sdf   sdf.SDF3
+	theta float64
+	bb    r3.Box
+}
+
+// layoutM44 has the memory layout of sdf.m44.
+type layoutM44 struct {
+	x00 float64
+	x01 float64
+	x02 float64
+	x03 float64
+	x10 float64
+	x11 float64
+	x12 float64
+	x13 float64
+	x20 float64
+	x21 float64
+	x22 float64
+	x23 float64
+	x30 float64
+	x31 float64
+	x32 float64
+	x33 float64
+}
+
+// layoutRotateUnion has the memory layout of sdf.rotateUnion.
+type layoutRotateUnion struct {
+	sdf  sdf.SDF3
+	num  int
+	step layoutM44
+	min  sdf.MinFunc
+	bb   r3.Box
+}
+
+// layoutScaleUniform3 has the memory layout of sdf.scaleUniform3.
+type layoutScaleUniform3 struct {
+	sdf  sdf.SDF3
+	k    float64
+	invK float64
+	bb   r3.Box
+}
+
+// layoutShell3 has the memory layout of sdf.shell3.
+type layoutShell3 struct {
+	sdf   sdf.SDF3
+	delta float64
+	bb    r3.Box
+}
+
+// layoutTransform3 has the memory layout of sdf.transform3.
+type layoutTransform3 struct {
+	sdf     sdf.SDF3
+	matrix  layoutM44
+	inverse layoutM44
+	bb      r3.Box
+}
+
+// layoutUnion3 has the memory layout of sdf.union3.
+type layoutUnion3 struct {
+	sdf []sdf.SDF3
+	min sdf.MinFunc
+	bb  r3.Box
+}
+
+// layoutCutSDF2 has the memory layout of sdf.CutSDF2.
+type layoutCutSDF2 struct {
+	sdf sdf.SDF2
+	a   r2.Vec
+	n   r2.Vec
+	bb  r2.Box
+}
+
+// layoutScaleUniformSDF2 has the memory layout of sdf.ScaleUniformSDF2.
+type layoutScaleUniformSDF2 struct {
+	sdf  sdf.SDF2
+	k    float64
+	invk float64
+	bb   r2.Box
+}
+
+// layoutM33 has the memory layout of sdf.m33.
+type layoutM33 struct {
+	x00 float64
+	x01 float64
+	x02 float64
+	x10 float64
+	x11 float64
+	x12 float64
+	x20 float64
+	x21 float64
+	x22 float64
+}
+
+// layoutTransformSDF2 has the memory layout of sdf.TransformSDF2.
+type layoutTransformSDF2 struct {
+	sdf  sdf.SDF2
+	mInv layoutM33
+	bb   r2.Box
+}
+
+// layoutArray2 has the memory layout of sdf.array2.
+type layoutArray2 struct {
+	sdf  sdf.SDF2
+	num  sdf.V2i
+	step r2.Vec
+	min  sdf.MinFunc
+	bb   r2.Box
+}
+
+// layoutDiff2 has the memory layout of sdf.diff2.
+type layoutDiff2 struct {
+	s0  sdf.SDF2
+	s1  sdf.SDF2
+	max sdf.MaxFunc
+	bb  r2.Box
+}
+
+// layoutElongate2 has the memory layout of sdf.elongate2.
+type layoutElongate2 struct {
+	sdf sdf.SDF2
+	hp  r2.Vec
+	hn  r2.Vec
+	bb  r2.Box
+}
+
+// layoutEmpty2 has the memory layout of sdf.empty2.
+type layoutEmpty2 struct {
+	center r2.Vec
+}
+
+// layoutIntersection2 has the memory layout of sdf.intersection2.
+type layoutIntersection2 struct {
+	s0  sdf.SDF2
+	s1  sdf.SDF2
+	max sdf.MaxFunc
+	bb  r2.Box
+}
+
+// layoutOffset2 has the memory layout of sdf.offset2.
+type layoutOffset2 struct {
+	sdf    sdf.SDF2
+	offset float64
+	bb     r2.Box
+}
+
+// layoutRotateCopy2 has the memory layout of sdf.rotateCopy2.
+type layoutRotateCopy2 struct {
+	sdf   sdf.SDF2
+	theta float64
+	bb    r2.Box
+}
+
+// layoutRotateUnion2 has the memory layout of sdf.rotateUnion2.
+type layoutRotateUnion2 struct {
+	sdf  sdf.SDF2
+	num  int
+	step layoutM33
+	min  sdf.MinFunc
+	bb   r2.Box
+}
+
+// layoutSlice2 has the memory layout of sdf.slice2.
+type layoutSlice2 struct {
+	sdf sdf.SDF3
+	a   r3.Vec
+	u   r3.Vec
+	v   r3.Vec
+	bb  r2.Box
+}
+
+// layoutUnion2 has the memory layout of sdf.union2.
+type layoutUnion2 struct {
+	sdf []sdf.SDF2
+	min sdf.MinFunc
+	bb  r2.Box
+}
@@ -0,0 +1,89 @@
+package sdf_viewer_go_auto
+
+import (
+	"flag"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	sdfviewergoauto "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto/childrengen"
+	"github.com/soypat/sdf"
+	"github.com/soypat/sdf/form3"
+	"gonum.org/v1/gonum/spatial/r3"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the generated children extractors")
+
+// TestChildrenExtractors checks that the generated children extractors are up to date.
+//
+//go:generate go test -run TestChildrenExtractors -update .
+func TestChildrenExtractors(t *testing.T) {
+	got, err := childrengen.Generate("github.com/soypat/sdf", "SDF3", "sdf_viewer_go_auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err = os.WriteFile("children_gen.go", []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile("children_gen.go")
+	if err != nil {
+		t.Fatalf("%v (run `go generate` to create it)", err)
+	}
+	if string(expected) != got {
+		t.Errorf("children_gen.go is out of date (run `go generate` after updating github.com/soypat/sdf)")
+	}
+}
+
+// testScene returns an SDF3 with several levels of nodes, which store their children in different ways.
+func testScene() sdf.SDF3 {
+	a, _ := form3.Box(r3.Vec{X: 1, Y: 1, Z: 1}, 0.1)
+	b, _ := form3.Sphere(0.75)
+	c, _ := form3.Cylinder(2, 0.25, 0)
+	m := sdf.Translate3D(r3.Vec{X: 0.5}).Mul(sdf.RotateY(math.Pi / 2))
+	return sdf.Difference3D(sdf.Union3D(a, b), sdf.Transform3D(c, m))
+}
+
+// TestChildrenExtractorsMatchReflection checks that the generated children extractors find the same children (with the
+// same names) as reflection.
+func TestChildrenExtractorsMatchReflection(t *testing.T) {
+	scene := testScene()
+	expectedNames, expectedNodes := descendants(NewSDF(scene))
+	var names []string
+	var nodes []interface{}
+	sdfviewergoauto.WithoutChildrenExtractors(func() {
+		names, nodes = descendants(NewSDF(scene))
+	})
+	if len(names) < 6 {
+		t.Fatalf("expected at least 6 nodes, got %q", names)
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("expected the names %q, got %q", expectedNames, names)
+	}
+	for i := range nodes {
+		if nodes[i] != expectedNodes[i] {
+			t.Fatalf("expected node %s to be %#v, got %#v", names[i], expectedNodes[i], nodes[i])
+		}
+	}
+}
+
+// descendants returns the names and the nodes of the underlying library of the SDF and all its descendants, in
+// depth-first order.
+func descendants(s sdfviewergo.SDF) (names []string, nodes []interface{}) {
+	names = append(names, s.Name())
+	switch s := s.(type) {
+	case *SDFWrapper:
+		nodes = append(nodes, s.SDF.SDF.SDFCoreChildrenRoot())
+	case *sdfviewergoauto.SDF:
+		nodes = append(nodes, s.SDF.SDFCoreChildrenRoot())
+	}
+	for _, child := range s.Children() {
+		childNames, childNodes := descendants(child)
+		names, nodes = append(names, childNames...), append(nodes, childNodes...)
+	}
+	return names, nodes
+}
@@ -4,13 +4,14 @@ import (
 	sdfviewergoauto "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
 	"github.com/soypat/sdf"
 	"gonum.org/v1/gonum/spatial/r3"
+	"math"
 	"reflect"
 )
 
 var _ sdfviewergoauto.SDFCore = &SDFCore{}
 
 func NewSDF(s sdf.SDF3) *SDFWrapper {
-	return &SDFWrapper{sdfviewergoauto.NewSDF(&SDFCore{s}, reflect.TypeOf((*sdf.SDF3)(nil)).Elem(), func(s interface{}) (sdfviewergoauto.SDFCore, bool) {
+	res := &SDFWrapper{sdfviewergoauto.NewSDF(&SDFCore{s}, reflect.TypeOf((*sdf.SDF3)(nil)).Elem(), func(s interface{}) (sdfviewergoauto.SDFCore, bool) {
 		s2, ok := s.(sdf.SDF3)
 		if ok {
 			if _, ok2 := s2.(*SDFWrapper); ok2 {
@@ -21,6 +22,13 @@ func NewSDF(s sdf.SDF3) *SDFWrapper {
 			return nil, false
 		}
 	})}
+	res.PerturbCore = func(child interface{}, offset float32) interface{} {
+		if s2, ok := child.(sdf.SDF3); ok {
+			return sdf.SDF3(&perturbedSDF3{s2, float64(offset)})
+		}
+		return nil
+	}
+	return res
 }
 
 type SDFCore struct {
@@ -58,3 +66,20 @@ func (s *SDFWrapper) Evaluate(p r3.Vec) float64 {
 func (s *SDFWrapper) Bounds() r3.Box {
 	return s.SDF.SDF.(*SDFCore).SDF3.Bounds()
 }
+
+// perturbedSDF3 offsets the distances of an SDF3, to find the children that are really used (see
+// sdfviewergoauto.SDF.PruneUnusedChildren).
+type perturbedSDF3 struct {
+	sdf.SDF3
+	offset float64
+}
+
+func (s *perturbedSDF3) Evaluate(p r3.Vec) float64 {
+	return s.SDF3.Evaluate(p) + s.offset
+}
+
+func (s *perturbedSDF3) Bounds() r3.Box {
+	box, grow := s.SDF3.Bounds(), math.Abs(s.offset)
+	return r3.Box{Min: r3.Sub(box.Min, r3.Vec{X: grow, Y: grow, Z: grow}),
+		Max: r3.Add(box.Max, r3.Vec{X: grow, Y: grow, Z: grow})}
+}
@@ -0,0 +1,830 @@
+// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.
+
+package sdf_viewer_go_auto
+
+import (
+	sdfviewergoauto "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
+	"github.com/deadsy/sdfx/sdf"
+	"github.com/deadsy/sdfx/vec/p2"
+	"github.com/deadsy/sdfx/vec/v2"
+	"github.com/deadsy/sdfx/vec/v2i"
+	"github.com/deadsy/sdfx/vec/v3"
+	"github.com/deadsy/sdfx/vec/v3i"
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+func init() {
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ArraySDF3", unsafe.Sizeof(layoutArraySDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutArraySDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutArraySDF3{}.num), Kind: reflect.Struct},
+		{Name: "step", Offset: unsafe.Offsetof(layoutArraySDF3{}.step), Kind: reflect.Struct},
+		{Name: "min", Offset: unsafe.Offsetof(layoutArraySDF3{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutArraySDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutArraySDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.BoxSDF3", unsafe.Sizeof(layoutBoxSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "size", Offset: unsafe.Offsetof(layoutBoxSDF3{}.size), Kind: reflect.Struct},
+		{Name: "round", Offset: unsafe.Offsetof(layoutBoxSDF3{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutBoxSDF3{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ConeSDF3", unsafe.Sizeof(layoutConeSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "r0", Offset: unsafe.Offsetof(layoutConeSDF3{}.r0), Kind: reflect.Float64},
+		{Name: "r1", Offset: unsafe.Offsetof(layoutConeSDF3{}.r1), Kind: reflect.Float64},
+		{Name: "height", Offset: unsafe.Offsetof(layoutConeSDF3{}.height), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutConeSDF3{}.round), Kind: reflect.Float64},
+		{Name: "u", Offset: unsafe.Offsetof(layoutConeSDF3{}.u), Kind: reflect.Struct},
+		{Name: "n", Offset: unsafe.Offsetof(layoutConeSDF3{}.n), Kind: reflect.Struct},
+		{Name: "l", Offset: unsafe.Offsetof(layoutConeSDF3{}.l), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutConeSDF3{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CutSDF3", unsafe.Sizeof(layoutCutSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutCutSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutCutSDF3{}.a), Kind: reflect.Struct},
+		{Name: "n", Offset: unsafe.Offsetof(layoutCutSDF3{}.n), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCutSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutCutSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CylinderSDF3", unsafe.Sizeof(layoutCylinderSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "height", Offset: unsafe.Offsetof(layoutCylinderSDF3{}.height), Kind: reflect.Float64},
+		{Name: "radius", Offset: unsafe.Offsetof(layoutCylinderSDF3{}.radius), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutCylinderSDF3{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCylinderSDF3{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.DifferenceSDF3", unsafe.Sizeof(layoutDifferenceSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutDifferenceSDF3{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutDifferenceSDF3{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutDifferenceSDF3{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutDifferenceSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutDifferenceSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ElongateSDF3", unsafe.Sizeof(layoutElongateSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutElongateSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "hp", Offset: unsafe.Offsetof(layoutElongateSDF3{}.hp), Kind: reflect.Struct},
+		{Name: "hn", Offset: unsafe.Offsetof(layoutElongateSDF3{}.hn), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutElongateSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutElongateSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ExtrudeRoundedSDF3", unsafe.Sizeof(layoutExtrudeRoundedSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutExtrudeRoundedSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutExtrudeRoundedSDF3{}.height), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutExtrudeRoundedSDF3{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutExtrudeRoundedSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutExtrudeRoundedSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ExtrudeSDF3", unsafe.Sizeof(layoutExtrudeSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutExtrudeSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutExtrudeSDF3{}.height), Kind: reflect.Float64},
+		{Name: "extrude", Offset: unsafe.Offsetof(layoutExtrudeSDF3{}.extrude), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutExtrudeSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutExtrudeSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.GyroidSDF3", unsafe.Sizeof(layoutGyroidSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "k", Offset: unsafe.Offsetof(layoutGyroidSDF3{}.k), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.IntersectionSDF3", unsafe.Sizeof(layoutIntersectionSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutIntersectionSDF3{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutIntersectionSDF3{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutIntersectionSDF3{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutIntersectionSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutIntersectionSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.LoftSDF3", unsafe.Sizeof(layoutLoftSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf0", Offset: unsafe.Offsetof(layoutLoftSDF3{}.sdf0), Kind: reflect.Interface},
+		{Name: "sdf1", Offset: unsafe.Offsetof(layoutLoftSDF3{}.sdf1), Kind: reflect.Interface},
+		{Name: "height", Offset: unsafe.Offsetof(layoutLoftSDF3{}.height), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutLoftSDF3{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutLoftSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutLoftSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf0", Pointer: &n.sdf0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf1", Pointer: &n.sdf1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.MeshSDF3", unsafe.Sizeof(layoutMeshSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "mesh", Offset: unsafe.Offsetof(layoutMeshSDF3{}.mesh), Kind: reflect.Slice},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutMeshSDF3{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.MeshSDF3Slow", unsafe.Sizeof(layoutMeshSDF3Slow{}), []sdfviewergoauto.FieldLayout{
+		{Name: "mesh", Offset: unsafe.Offsetof(layoutMeshSDF3Slow{}.mesh), Kind: reflect.Slice},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutMeshSDF3Slow{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.OffsetSDF3", unsafe.Sizeof(layoutOffsetSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutOffsetSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "offset", Offset: unsafe.Offsetof(layoutOffsetSDF3{}.offset), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutOffsetSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutOffsetSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.RotateCopySDF3", unsafe.Sizeof(layoutRotateCopySDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateCopySDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutRotateCopySDF3{}.theta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateCopySDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateCopySDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.RotateUnionSDF3", unsafe.Sizeof(layoutRotateUnionSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateUnionSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutRotateUnionSDF3{}.num), Kind: reflect.Int},
+		{Name: "step", Offset: unsafe.Offsetof(layoutRotateUnionSDF3{}.step), Kind: reflect.Array},
+		{Name: "min", Offset: unsafe.Offsetof(layoutRotateUnionSDF3{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateUnionSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateUnionSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ScaleUniformSDF3", unsafe.Sizeof(layoutScaleUniformSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutScaleUniformSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "k", Offset: unsafe.Offsetof(layoutScaleUniformSDF3{}.k), Kind: reflect.Float64},
+		{Name: "invK", Offset: unsafe.Offsetof(layoutScaleUniformSDF3{}.invK), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutScaleUniformSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutScaleUniformSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ScrewSDF3", unsafe.Sizeof(layoutScrewSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "thread", Offset: unsafe.Offsetof(layoutScrewSDF3{}.thread), Kind: reflect.Interface},
+		{Name: "pitch", Offset: unsafe.Offsetof(layoutScrewSDF3{}.pitch), Kind: reflect.Float64},
+		{Name: "lead", Offset: unsafe.Offsetof(layoutScrewSDF3{}.lead), Kind: reflect.Float64},
+		{Name: "length", Offset: unsafe.Offsetof(layoutScrewSDF3{}.length), Kind: reflect.Float64},
+		{Name: "taper", Offset: unsafe.Offsetof(layoutScrewSDF3{}.taper), Kind: reflect.Float64},
+		{Name: "starts", Offset: unsafe.Offsetof(layoutScrewSDF3{}.starts), Kind: reflect.Int},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutScrewSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutScrewSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "thread", Pointer: &n.thread})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ShellSDF3", unsafe.Sizeof(layoutShellSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutShellSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "delta", Offset: unsafe.Offsetof(layoutShellSDF3{}.delta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutShellSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutShellSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.SorSDF3", unsafe.Sizeof(layoutSorSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutSorSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutSorSDF3{}.theta), Kind: reflect.Float64},
+		{Name: "norm", Offset: unsafe.Offsetof(layoutSorSDF3{}.norm), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutSorSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutSorSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.SphereSDF3", unsafe.Sizeof(layoutSphereSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "radius", Offset: unsafe.Offsetof(layoutSphereSDF3{}.radius), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutSphereSDF3{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.TransformSDF3", unsafe.Sizeof(layoutTransformSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutTransformSDF3{}.sdf), Kind: reflect.Interface},
+		{Name: "matrix", Offset: unsafe.Offsetof(layoutTransformSDF3{}.matrix), Kind: reflect.Array},
+		{Name: "inverse", Offset: unsafe.Offsetof(layoutTransformSDF3{}.inverse), Kind: reflect.Array},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutTransformSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutTransformSDF3)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.UnionSDF3", unsafe.Sizeof(layoutUnionSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutUnionSDF3{}.sdf), Kind: reflect.Slice},
+		{Name: "min", Offset: unsafe.Offsetof(layoutUnionSDF3{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutUnionSDF3{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutUnionSDF3)(node)
+		for i2 := range n.sdf {
+			res = append(res, sdfviewergoauto.NodeField{Name: "sdf[" + strconv.Itoa(i2) + "]", Pointer: &n.sdf[i2]})
+		}
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.VoxelSDF3", unsafe.Sizeof(layoutVoxelSDF3{}), []sdfviewergoauto.FieldLayout{
+		{Name: "voxelCorners", Offset: unsafe.Offsetof(layoutVoxelSDF3{}.voxelCorners), Kind: reflect.Map},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutVoxelSDF3{}.bb), Kind: reflect.Struct},
+		{Name: "numVoxels", Offset: unsafe.Offsetof(layoutVoxelSDF3{}.numVoxels), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ArcSpiralSDF2", unsafe.Sizeof(layoutArcSpiralSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "spiral", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral), Kind: reflect.Struct},
+		{Name: "spiral.a", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral) + unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral.a), Kind: reflect.Float64},
+		{Name: "spiral.n", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral) + unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral.n), Kind: reflect.Float64},
+		{Name: "spiral.k", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral) + unsafe.Offsetof(layoutArcSpiralSDF2{}.spiral.k), Kind: reflect.Float64},
+		{Name: "d", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.d), Kind: reflect.Float64},
+		{Name: "start", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.start), Kind: reflect.Struct},
+		{Name: "end", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.end), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutArcSpiralSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ArraySDF2", unsafe.Sizeof(layoutArraySDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutArraySDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutArraySDF2{}.num), Kind: reflect.Struct},
+		{Name: "step", Offset: unsafe.Offsetof(layoutArraySDF2{}.step), Kind: reflect.Struct},
+		{Name: "min", Offset: unsafe.Offsetof(layoutArraySDF2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutArraySDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutArraySDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.BoxSDF2", unsafe.Sizeof(layoutBoxSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "size", Offset: unsafe.Offsetof(layoutBoxSDF2{}.size), Kind: reflect.Struct},
+		{Name: "round", Offset: unsafe.Offsetof(layoutBoxSDF2{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutBoxSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CacheSDF2", unsafe.Sizeof(layoutCacheSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutCacheSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "cache", Offset: unsafe.Offsetof(layoutCacheSDF2{}.cache), Kind: reflect.Map},
+		{Name: "reads", Offset: unsafe.Offsetof(layoutCacheSDF2{}.reads), Kind: reflect.Uint},
+		{Name: "hits", Offset: unsafe.Offsetof(layoutCacheSDF2{}.hits), Kind: reflect.Uint},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutCacheSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CircleSDF2", unsafe.Sizeof(layoutCircleSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "radius", Offset: unsafe.Offsetof(layoutCircleSDF2{}.radius), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCircleSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CubicSplineSDF2", unsafe.Sizeof(layoutCubicSplineSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "spline", Offset: unsafe.Offsetof(layoutCubicSplineSDF2{}.spline), Kind: reflect.Slice},
+		{Name: "maxiters", Offset: unsafe.Offsetof(layoutCubicSplineSDF2{}.maxiters), Kind: reflect.Int},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCubicSplineSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.CutSDF2", unsafe.Sizeof(layoutCutSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutCutSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutCutSDF2{}.a), Kind: reflect.Struct},
+		{Name: "n", Offset: unsafe.Offsetof(layoutCutSDF2{}.n), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutCutSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutCutSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.DifferenceSDF2", unsafe.Sizeof(layoutDifferenceSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutDifferenceSDF2{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutDifferenceSDF2{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutDifferenceSDF2{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutDifferenceSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutDifferenceSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ElongateSDF2", unsafe.Sizeof(layoutElongateSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutElongateSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "hp", Offset: unsafe.Offsetof(layoutElongateSDF2{}.hp), Kind: reflect.Struct},
+		{Name: "hn", Offset: unsafe.Offsetof(layoutElongateSDF2{}.hn), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutElongateSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutElongateSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.Flange1", unsafe.Sizeof(layoutFlange1{}), []sdfviewergoauto.FieldLayout{
+		{Name: "distance", Offset: unsafe.Offsetof(layoutFlange1{}.distance), Kind: reflect.Float64},
+		{Name: "centerRadius", Offset: unsafe.Offsetof(layoutFlange1{}.centerRadius), Kind: reflect.Float64},
+		{Name: "sideRadius", Offset: unsafe.Offsetof(layoutFlange1{}.sideRadius), Kind: reflect.Float64},
+		{Name: "a", Offset: unsafe.Offsetof(layoutFlange1{}.a), Kind: reflect.Struct},
+		{Name: "u", Offset: unsafe.Offsetof(layoutFlange1{}.u), Kind: reflect.Struct},
+		{Name: "l", Offset: unsafe.Offsetof(layoutFlange1{}.l), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutFlange1{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.FlatFlankCamSDF2", unsafe.Sizeof(layoutFlatFlankCamSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "distance", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.distance), Kind: reflect.Float64},
+		{Name: "baseRadius", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.baseRadius), Kind: reflect.Float64},
+		{Name: "noseRadius", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.noseRadius), Kind: reflect.Float64},
+		{Name: "a", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.a), Kind: reflect.Struct},
+		{Name: "u", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.u), Kind: reflect.Struct},
+		{Name: "l", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.l), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutFlatFlankCamSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.GearRackSDF2", unsafe.Sizeof(layoutGearRackSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "tooth", Offset: unsafe.Offsetof(layoutGearRackSDF2{}.tooth), Kind: reflect.Interface},
+		{Name: "pitch", Offset: unsafe.Offsetof(layoutGearRackSDF2{}.pitch), Kind: reflect.Float64},
+		{Name: "length", Offset: unsafe.Offsetof(layoutGearRackSDF2{}.length), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutGearRackSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutGearRackSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "tooth", Pointer: &n.tooth})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.IntersectionSDF2", unsafe.Sizeof(layoutIntersectionSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "s0", Offset: unsafe.Offsetof(layoutIntersectionSDF2{}.s0), Kind: reflect.Interface},
+		{Name: "s1", Offset: unsafe.Offsetof(layoutIntersectionSDF2{}.s1), Kind: reflect.Interface},
+		{Name: "max", Offset: unsafe.Offsetof(layoutIntersectionSDF2{}.max), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutIntersectionSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutIntersectionSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "s0", Pointer: &n.s0})
+		res = append(res, sdfviewergoauto.NodeField{Name: "s1", Pointer: &n.s1})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.LineSDF2", unsafe.Sizeof(layoutLineSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "l", Offset: unsafe.Offsetof(layoutLineSDF2{}.l), Kind: reflect.Float64},
+		{Name: "round", Offset: unsafe.Offsetof(layoutLineSDF2{}.round), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutLineSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.MeshSDF2Slow", unsafe.Sizeof(layoutMeshSDF2Slow{}), []sdfviewergoauto.FieldLayout{
+		{Name: "mesh", Offset: unsafe.Offsetof(layoutMeshSDF2Slow{}.mesh), Kind: reflect.Slice},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutMeshSDF2Slow{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.OffsetSDF2", unsafe.Sizeof(layoutOffsetSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutOffsetSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "offset", Offset: unsafe.Offsetof(layoutOffsetSDF2{}.offset), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutOffsetSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutOffsetSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.RotateCopySDF2", unsafe.Sizeof(layoutRotateCopySDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateCopySDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "theta", Offset: unsafe.Offsetof(layoutRotateCopySDF2{}.theta), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateCopySDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateCopySDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.RotateUnionSDF2", unsafe.Sizeof(layoutRotateUnionSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutRotateUnionSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "num", Offset: unsafe.Offsetof(layoutRotateUnionSDF2{}.num), Kind: reflect.Int},
+		{Name: "step", Offset: unsafe.Offsetof(layoutRotateUnionSDF2{}.step), Kind: reflect.Array},
+		{Name: "min", Offset: unsafe.Offsetof(layoutRotateUnionSDF2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutRotateUnionSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutRotateUnionSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ScaleUniformSDF2", unsafe.Sizeof(layoutScaleUniformSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "k", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.k), Kind: reflect.Float64},
+		{Name: "invk", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.invk), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutScaleUniformSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutScaleUniformSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.SliceSDF2", unsafe.Sizeof(layoutSliceSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutSliceSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "a", Offset: unsafe.Offsetof(layoutSliceSDF2{}.a), Kind: reflect.Struct},
+		{Name: "u", Offset: unsafe.Offsetof(layoutSliceSDF2{}.u), Kind: reflect.Struct},
+		{Name: "v", Offset: unsafe.Offsetof(layoutSliceSDF2{}.v), Kind: reflect.Struct},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutSliceSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutSliceSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.ThreeArcCamSDF2", unsafe.Sizeof(layoutThreeArcCamSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "distance", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.distance), Kind: reflect.Float64},
+		{Name: "baseRadius", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.baseRadius), Kind: reflect.Float64},
+		{Name: "noseRadius", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.noseRadius), Kind: reflect.Float64},
+		{Name: "flankRadius", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.flankRadius), Kind: reflect.Float64},
+		{Name: "flankCenter", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.flankCenter), Kind: reflect.Struct},
+		{Name: "thetaBase", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.thetaBase), Kind: reflect.Float64},
+		{Name: "thetaNose", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.thetaNose), Kind: reflect.Float64},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutThreeArcCamSDF2{}.bb), Kind: reflect.Struct},
+	}, nil)
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.TransformSDF2", unsafe.Sizeof(layoutTransformSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutTransformSDF2{}.sdf), Kind: reflect.Interface},
+		{Name: "mInv", Offset: unsafe.Offsetof(layoutTransformSDF2{}.mInv), Kind: reflect.Array},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutTransformSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutTransformSDF2)(node)
+		res = append(res, sdfviewergoauto.NodeField{Name: "sdf", Pointer: &n.sdf})
+		return res
+	})
+	sdfviewergoauto.RegisterChildrenExtractor("github.com/deadsy/sdfx/sdf.UnionSDF2", unsafe.Sizeof(layoutUnionSDF2{}), []sdfviewergoauto.FieldLayout{
+		{Name: "sdf", Offset: unsafe.Offsetof(layoutUnionSDF2{}.sdf), Kind: reflect.Slice},
+		{Name: "min", Offset: unsafe.Offsetof(layoutUnionSDF2{}.min), Kind: reflect.Func},
+		{Name: "bb", Offset: unsafe.Offsetof(layoutUnionSDF2{}.bb), Kind: reflect.Struct},
+	}, func(node unsafe.Pointer) (res []sdfviewergoauto.NodeField) {
+		n := (*layoutUnionSDF2)(node)
+		for i2 := range n.sdf {
+			res = append(res, sdfviewergoauto.NodeField{Name: "sdf[" + strconv.Itoa(i2) + "]", Pointer: &n.sdf[i2]})
+		}
+		return res
+	})
+}
+
+// layoutArraySDF3 has the memory layout of sdf.ArraySDF3.
+type layoutArraySDF3 struct {
+	sdf  sdf.SDF3
+	num  v3i.Vec
+	step v3.Vec
+	min  sdf.MinFunc
+	bb   sdf.Box3
+}
+
+// layoutBoxSDF3 has the memory layout of sdf.BoxSDF3.
+type layoutBoxSDF3 struct {
+	size  v3.Vec
+	round float64
+	bb    sdf.Box3
+}
+
+// layoutConeSDF3 has the memory layout of sdf.ConeSDF3.
+type layoutConeSDF3 struct {
+	r0     float64
+	r1     float64
+	height float64
+	round  float64
+	u      v2.Vec
+	n      v2.Vec
+	l      float64
+	bb     sdf.Box3
+}
+
+// layoutCutSDF3 has the memory layout of sdf.CutSDF3.
+type layoutCutSDF3 struct {
+	sdf sdf.SDF3
+	a   v3.Vec
+	n   v3.Vec
+	bb  sdf.Box3
+}
+
+// layoutCylinderSDF3 has the memory layout of sdf.CylinderSDF3.
+type layoutCylinderSDF3 struct {
+	height float64
+	radius float64
+	round  float64
+	bb     sdf.Box3
+}
+
+// layoutDifferenceSDF3 has the memory layout of sdf.DifferenceSDF3.
+type layoutDifferenceSDF3 struct {
+	s0  sdf.SDF3
+	s1  sdf.SDF3
+	max sdf.MaxFunc
+	bb  sdf.Box3
+}
+
+// layoutElongateSDF3 has the memory layout of sdf.ElongateSDF3.
+type layoutElongateSDF3 struct {
+	sdf sdf.SDF3
+	hp  v3.Vec
+	hn  v3.Vec
+	bb  sdf.Box3
+}
+
+// layoutExtrudeRoundedSDF3 has the memory layout of sdf.ExtrudeRoundedSDF3.
+type layoutExtrudeRoundedSDF3 struct {
+	sdf    sdf.SDF2
+	height float64
+	round  float64
+	bb     sdf.Box3
+}
+
+// layoutExtrudeSDF3 has the memory layout of sdf.ExtrudeSDF3.
+type layoutExtrudeSDF3 struct {
+	sdf     sdf.SDF2
+	height  float64
+	extrude sdf.ExtrudeFunc
+	bb      sdf.Box3
+}
+
+// layoutGyroidSDF3 has the memory layout of sdf.GyroidSDF3.
+type layoutGyroidSDF3 struct {
+	k v3.Vec
+}
+
+// layoutIntersectionSDF3 has the memory layout of sdf.IntersectionSDF3.
+type layoutIntersectionSDF3 struct {
+	s0  sdf.SDF3
+	s1  sdf.SDF3
+	max sdf.MaxFunc
+	bb  sdf.Box3
+}
+
+// layoutLoftSDF3 has the memory layout of sdf.LoftSDF3.
+type layoutLoftSDF3 struct {
+	sdf0   sdf.SDF2
+	sdf1   sdf.SDF2
+	height float64
+	round  float64
+	bb     sdf.Box3
+}
+
+// layoutMeshSDF3 has the memory layout of sdf.MeshSDF3.
+type layoutMeshSDF3 struct {
+	mesh []*sdf.Triangle3
+	bb   sdf.Box3
+}
+
+// layoutMeshSDF3Slow has the memory layout of sdf.MeshSDF3Slow.
+type layoutMeshSDF3Slow struct {
+	mesh []*sdf.Triangle3
+	bb   sdf.Box3
+}
+
+// layoutOffsetSDF3 has the memory layout of sdf.OffsetSDF3.
+type layoutOffsetSDF3 struct {
+	sdf    sdf.SDF3
+	offset float64
+	bb     sdf.Box3
+}
+
+// layoutRotateCopySDF3 has the memory layout of sdf.RotateCopySDF3.
+type layoutRotateCopySDF3 struct {
+	sdf   sdf.SDF3
+	theta float64
+	bb    sdf.Box3
+}
+
+// layoutRotateUnionSDF3 has the memory layout of sdf.RotateUnionSDF3.
+type layoutRotateUnionSDF3 struct {
+	sdf  sdf.SDF3
+	num  int
+	step sdf.M44
+	min  sdf.MinFunc
+	bb   sdf.Box3
+}
+
+// layoutScaleUniformSDF3 has the memory layout of sdf.ScaleUniformSDF3.
+type layoutScaleUniformSDF3 struct {
+	sdf  sdf.SDF3
+	k    float64
+	invK float64
+	bb   sdf.Box3
+}
+
+// layoutScrewSDF3 has the memory layout of sdf.ScrewSDF3.
+type layoutScrewSDF3 struct {
+	thread sdf.SDF2
+	pitch  float64
+	lead   float64
+	length float64
+	taper  float64
+	starts int
+	bb     sdf.Box3
+}
+
+// layoutShellSDF3 has the memory layout of sdf.ShellSDF3.
+type layoutShellSDF3 struct {
+	sdf   sdf.SDF3
+	delta float64
+	bb    sdf.Box3
+}
+
+// layoutSorSDF3 has the memory layout of sdf.SorSDF3.
+type layoutSorSDF3 struct {
+	sdf   sdf.SDF2
+	theta float64
+	norm  v2.Vec
+	bb    sdf.Box3
+}
+
+// layoutSphereSDF3 has the memory layout of sdf.SphereSDF3.
+type layoutSphereSDF3 struct {
+	radius float64
+	bb     sdf.Box3
+}
+
+// layoutTransformSDF3 has the memory layout of sdf.TransformSDF3.
+type layoutTransformSDF3 struct {
+	sdf     sdf.SDF3
+	matrix  sdf.M44
+	inverse sdf.M44
+	bb      sdf.Box3
+}
+
+// layoutUnionSDF3 has the memory layout of sdf.UnionSDF3.
+type layoutUnionSDF3 struct {
+	sdf []sdf.SDF3
+	min sdf.MinFunc
+	bb  sdf.Box3
+}
+
+// layoutVoxelSDF3 has the memory layout of sdf.VoxelSDF3.
+type layoutVoxelSDF3 struct {
+	voxelCorners unsafe.Pointer
+	bb           sdf.Box3
+	numVoxels    v3i.Vec
+}
+
+// layoutArcSpiral has the memory layout of sdf.arcSpiral.
+type layoutArcSpiral struct {
+	a float64
+	n float64
+	k float64
+}
+
+// layoutArcSpiralSDF2 has the memory layout of sdf.ArcSpiralSDF2.
+type layoutArcSpiralSDF2 struct {
+	spiral layoutArcSpiral
+	d      float64
+	start  p2.Vec
+	end    p2.Vec
+	bb     sdf.Box2
+}
+
+// layoutArraySDF2 has the memory layout of sdf.ArraySDF2.
+type layoutArraySDF2 struct {
+	sdf  sdf.SDF2
+	num  v2i.Vec
+	step v2.Vec
+	min  sdf.MinFunc
+	bb   sdf.Box2
+}
+
+// layoutBoxSDF2 has the memory layout of sdf.BoxSDF2.
+type layoutBoxSDF2 struct {
+	size  v2.Vec
+	round float64
+	bb    sdf.Box2
+}
+
+// layoutCacheSDF2 has the memory layout of sdf.CacheSDF2.
+type layoutCacheSDF2 struct {
+	sdf   sdf.SDF2
+	cache unsafe.Pointer
+	reads uint
+	hits  uint
+}
+
+// layoutCircleSDF2 has the memory layout of sdf.CircleSDF2.
+type layoutCircleSDF2 struct {
+	radius float64
+	bb     sdf.Box2
+}
+
+// layoutCubicSplineSDF2 has the memory layout of sdf.CubicSplineSDF2.
+type layoutCubicSplineSDF2 struct {
+	spline   []sdf.CubicSpline
+	maxiters int
+	bb       sdf.Box2
+}
+
+// layoutCutSDF2 has the memory layout of sdf.CutSDF2.
+type layoutCutSDF2 struct {
+	sdf sdf.SDF2
+	a   v2.Vec
+	n   v2.Vec
+	bb  sdf.Box2
+}
+
+// layoutDifferenceSDF2 has the memory layout of sdf.DifferenceSDF2.
+type layoutDifferenceSDF2 struct {
+	s0  sdf.SDF2
+	s1  sdf.SDF2
+	max sdf.MaxFunc
+	bb  sdf.Box2
+}
+
+// layoutElongateSDF2 has the memory layout of sdf.ElongateSDF2.
+type layoutElongateSDF2 struct {
+	sdf sdf.SDF2
+	hp  v2.Vec
+	hn  v2.Vec
+	bb  sdf.Box2
+}
+
+// layoutFlange1 has the memory layout of sdf.Flange1.
+type layoutFlange1 struct {
+	distance     float64
+	centerRadius float64
+	sideRadius   float64
+	a            v2.Vec
+	u            v2.Vec
+	l            float64
+	bb           sdf.Box2
+}
+
+// layoutFlatFlankCamSDF2 has the memory layout of sdf.FlatFlankCamSDF2.
+type layoutFlatFlankCamSDF2 struct {
+	distance   float64
+	baseRadius float64
+	noseRadius float64
+	a          v2.Vec
+	u          v2.Vec
+	l          float64
+	bb         sdf.Box2
+}
+
+// layoutGearRackSDF2 has the memory layout of sdf.GearRackSDF2.
+type layoutGearRackSDF2 struct {
+	tooth  sdf.SDF2
+	pitch  float64
+	length float64
+	bb     sdf.Box2
+}
+
+// layoutIntersectionSDF2 has the memory layout of sdf.IntersectionSDF2.
+type layoutIntersectionSDF2 struct {
+	s0  sdf.SDF2
+	s1  sdf.SDF2
+	max sdf.MaxFunc
+	bb  sdf.Box2
+}
+
+// layoutLineSDF2 has the memory layout of sdf.LineSDF2.
+type layoutLineSDF2 struct {
+	l     float64
+	round float64
+	bb    sdf.Box2
+}
+
+// layoutMeshSDF2Slow has the memory layout of sdf.MeshSDF2Slow.
+type layoutMeshSDF2Slow struct {
+	mesh []byte
+	bb   sdf.Box2
+}
+
+// layoutOffsetSDF2 has the memory layout of sdf.OffsetSDF2.
+type layoutOffsetSDF2 struct {
+	sdf    sdf.SDF2
+	offset float64
+	bb     sdf.Box2
+}
+
+// layoutRotateCopySDF2 has the memory layout of sdf.RotateCopySDF2.
+type layoutRotateCopySDF2 struct {
+	sdf   sdf.SDF2
+	theta float64
+	bb    sdf.Box2
+}
+
+// layoutRotateUnionSDF2 has the memory layout of sdf.RotateUnionSDF2.
+type layoutRotateUnionSDF2 struct {
+	sdf  sdf.SDF2
+	num  int
+	step sdf.M33
+	min  sdf.MinFunc
+	bb   sdf.Box2
+}
+
+// layoutScaleUniformSDF2 has the memory layout of sdf.ScaleUniformSDF2.
+type layoutScaleUniformSDF2 struct {
+	sdf  sdf.SDF2
+	k    float64
+	invk float64
+	bb   sdf.Box2
+}
+
+// layoutSliceSDF2 has the memory layout of sdf.SliceSDF2.
+type layoutSliceSDF2 struct {
+	sdf sdf.SDF3
+	a   v3.Vec
+	u   v3.Vec
+	v   v3.Vec
+	bb  sdf.Box2
+}
+
+// layoutThreeArcCamSDF2 has the memory layout of sdf.ThreeArcCamSDF2.
+type layoutThreeArcCamSDF2 struct {
+	distance    float64
+	baseRadius  float64
+	noseRadius  float64
+	flankRadius float64
+	flankCenter v2.Vec
+	thetaBase   float64
+	thetaNose   float64
+	bb          sdf.Box2
+}
+
+// layoutTransformSDF2 has the memory layout of sdf.TransformSDF2.
+type layoutTransformSDF2 struct {
+	sdf  sdf.SDF2
+	mInv sdf.M33
+	bb   sdf.Box2
+}
+
+// layoutUnionSDF2 has the memory layout of sdf.UnionSDF2.
+type layoutUnionSDF2 struct {
+	sdf []sdf.SDF2
+	min sdf.MinFunc
+	bb  sdf.Box2
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"flag"
 	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/render/rendertest"
 	"testing"
 )
 
+var update = flag.Bool("update", false, "update the golden images") // Looked up by rendertest
+
 func TestScene(t *testing.T) {
 	sdfviewergo.TestImpl(t, sceneSDF())
 }
 
+// TestSceneGolden checks the rendering of the scene (run `go test -update` after intended changes).
+func TestSceneGolden(t *testing.T) {
+	rendertest.Golden(t, "phone-case", sceneSDF())
+}
+
 func BenchmarkScene(t *testing.B) {
 	sdfviewergo.BenchmarkImpl(t, sceneSDF())
 }
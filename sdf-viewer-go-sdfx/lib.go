@@ -4,13 +4,14 @@ import (
 	sdfviewergoauto "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go-auto"
 	"github.com/deadsy/sdfx/sdf"
 	"github.com/deadsy/sdfx/vec/v3"
+	"math"
 	"reflect"
 )
 
 var _ sdfviewergoauto.SDFCore = &SDFCore{}
 
 func NewSDF(s sdf.SDF3) *SDFWrapper {
-	return &SDFWrapper{sdfviewergoauto.NewSDF(&SDFCore{s}, reflect.TypeOf((*sdf.SDF3)(nil)).Elem(), func(s interface{}) (sdfviewergoauto.SDFCore, bool) {
+	res := &SDFWrapper{sdfviewergoauto.NewSDF(&SDFCore{s}, reflect.TypeOf((*sdf.SDF3)(nil)).Elem(), func(s interface{}) (sdfviewergoauto.SDFCore, bool) {
 		s2, ok := s.(sdf.SDF3)
 		if ok {
 			if _, ok2 := s2.(*SDFWrapper); ok2 {
@@ -21,6 +22,13 @@ func NewSDF(s sdf.SDF3) *SDFWrapper {
 			return nil, false
 		}
 	})}
+	res.PerturbCore = func(child interface{}, offset float32) interface{} {
+		if s2, ok := child.(sdf.SDF3); ok {
+			return sdf.SDF3(&perturbedSDF3{s2, float64(offset)})
+		}
+		return nil
+	}
+	return res
 }
 
 type SDFCore struct {
@@ -59,3 +67,19 @@ func (s *SDFWrapper) Evaluate(p v3.Vec) float64 {
 func (s *SDFWrapper) BoundingBox() sdf.Box3 {
 	return s.SDF.SDF.(*SDFCore).SDF3.BoundingBox()
 }
+
+// perturbedSDF3 offsets the distances of an SDF3, to find the children that are really used (see
+// sdfviewergoauto.SDF.PruneUnusedChildren).
+type perturbedSDF3 struct {
+	sdf.SDF3
+	offset float64
+}
+
+func (s *perturbedSDF3) Evaluate(p v3.Vec) float64 {
+	return s.SDF3.Evaluate(p) + s.offset
+}
+
+func (s *perturbedSDF3) BoundingBox() sdf.Box3 {
+	grow := 2 * math.Abs(s.offset)
+	return s.SDF3.BoundingBox().Enlarge(v3.Vec{X: grow, Y: grow, Z: grow})
+}
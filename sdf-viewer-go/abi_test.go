@@ -0,0 +1,86 @@
+package sdf_viewer_go
+
+import (
+	"flag"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/abigen"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+var update = flag.Bool("update", false, "update the golden ABI layout and the generated C/Rust definitions")
+
+// abiStructs are all the structs shared with the SDF Viewer app, in dependency order.
+var abiStructs = []abigen.NamedType{
+	{Name: "PointerLength", Type: reflect.TypeOf(pointerLength{})},
+	{Name: "SdfSample", Type: reflect.TypeOf(SDFSample{})},
+	{Name: "SdfParamKindC", Type: reflect.TypeOf(sdfParamKindC{})},
+	{Name: "SdfParamValueC", Type: reflect.TypeOf(sdfParamValueC{})},
+	{Name: "SdfParamC", Type: reflect.TypeOf(sdfParamC{})},
+	{Name: "SetParameterRes", Type: reflect.TypeOf(setParameterRes{})},
+	{Name: "ChangedAABBC", Type: reflect.TypeOf(changedAABBC{})},
+	{Name: "LastErrorC", Type: reflect.TypeOf(lastErrorC{})},
+}
+
+// TestABILayout checks the layout of the structs shared with the SDF Viewer app against the golden wasm32 layout.
+func TestABILayout(t *testing.T) {
+	checkGolden(t, "testdata/abi_layout_wasm32.golden", abigen.LayoutTable(abiStructs, abigen.Wasm32WordSize))
+
+	// The computed layout must also match what the compiler does for the current target (wasm32 or native)
+	wordSize := unsafe.Sizeof(wasmWord(0))
+	for _, s := range abiStructs {
+		layout := abigen.StructLayout(s, wordSize)
+		if layout.Size != s.Type.Size() || uintptr(layout.Align) != uintptr(s.Type.Align()) {
+			t.Errorf("%s: computed size %d (align %d), but the compiler uses %d (align %d)",
+				s.Name, layout.Size, layout.Align, s.Type.Size(), s.Type.Align())
+		}
+		for i, field := range layout.Fields {
+			if offset := s.Type.Field(i).Offset; field.Offset != offset {
+				t.Errorf("%s.%s: computed offset %d, but the compiler uses %d", s.Name, field.Name, field.Offset, offset)
+			}
+		}
+	}
+	if wordSize == abigen.Wasm32WordSize {
+		// Explicit checks of the most important structs, on wasm32 only (see the CI workflow to run them)
+		checkSize(t, "pointerLength", unsafe.Sizeof(pointerLength{}), 8)
+		checkSize(t, "sdfParamC", unsafe.Sizeof(sdfParamC{}), 48)
+		checkSize(t, "sdfParamC.Description", unsafe.Offsetof(sdfParamC{}.Description), 40)
+		checkSize(t, "changedAABBC", unsafe.Sizeof(changedAABBC{}), 28)
+	}
+}
+
+// TestABIBindings checks that the generated C and Rust definitions are up to date.
+//
+//go:generate go test -run TestABI -update .
+func TestABIBindings(t *testing.T) {
+	checkGolden(t, "bindings/sdf_viewer_go.h", abigen.GenerateC(abiStructs))
+	checkGolden(t, "bindings/sdf_viewer_go.rs", abigen.GenerateRust(abiStructs))
+}
+
+func checkSize(t *testing.T, what string, got, expected uintptr) {
+	if got != expected {
+		t.Errorf("%s: expected %d, got %d", what, expected, got)
+	}
+}
+
+func checkGolden(t *testing.T, path, got string) {
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run `go generate` to create it)", err)
+	}
+	if string(expected) != got {
+		t.Errorf("%s is out of date (run `go generate` if the change is intended and the SDF Viewer app was updated):\n"+
+			"expected:\n%s\ngot:\n%s", path, expected, got)
+	}
+}
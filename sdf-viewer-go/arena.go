@@ -0,0 +1,79 @@
+package sdf_viewer_go
+
+// pinnedGenerations is the number of export calls whose returned memory is kept alive if the host never releases it
+// (e.g. older versions of the SDF Viewer app), which read it right after each call.
+const pinnedGenerations = 64
+
+// returnArena pins the memory returned to the host, as only its address is handed over and nothing else would keep it
+// alive (or even on the heap) after the export returns.
+//
+// Each export call that returns memory starts a new generation, and everything it returns is pinned under it until the
+// host releases it (see the `release` export). Hosts that never call `release` only get the memory returned by the
+// last pinnedGenerations calls pinned, but once `release` is called, generations are kept until they are released.
+type returnArena struct {
+	generation uint32
+	released   bool               // Whether the host ever released a generation
+	pinned     []pinnedGeneration // In generation order
+}
+
+type pinnedGeneration struct {
+	generation uint32
+	buffers    []interface{}
+}
+
+// arena is the arena for all exports.
+var arena = &returnArena{}
+
+// begin starts a new generation, releasing the oldest one if the host never releases them.
+func (a *returnArena) begin() {
+	a.generation++
+	if !a.released && len(a.pinned) >= pinnedGenerations {
+		a.drop(len(a.pinned) - pinnedGenerations + 1)
+	}
+	a.pinned = append(a.pinned, pinnedGeneration{generation: a.generation})
+}
+
+// pin keeps buf alive until the current generation is released.
+func (a *returnArena) pin(buf interface{}) {
+	if len(a.pinned) == 0 { // Only before the first generation
+		a.pinned = append(a.pinned, pinnedGeneration{generation: a.generation})
+	}
+	last := &a.pinned[len(a.pinned)-1]
+	last.buffers = append(last.buffers, buf)
+}
+
+// release unpins the memory returned by all calls up to (and including) the given generation.
+func (a *returnArena) release(generation uint32) {
+	a.released = true
+	count := 0
+	for count < len(a.pinned) && int32(generation-a.pinned[count].generation) >= 0 { // Generation numbers may wrap around
+		count++
+	}
+	a.drop(count)
+}
+
+// drop unpins the oldest count generations.
+func (a *returnArena) drop(count int) {
+	kept := copy(a.pinned, a.pinned[count:])
+	for i := kept; i < len(a.pinned); i++ {
+		a.pinned[i] = pinnedGeneration{} // Let the GC reclaim the buffers
+	}
+	a.pinned = a.pinned[:kept]
+}
+
+// returnPinned pins the result of an export until the host releases the current generation.
+func returnPinned[T any](res *T) *T {
+	arena.pin(res)
+	return res
+}
+
+//export returned_generation
+//goland:noinspection GoSnakeCaseUsage
+func returned_generation() uint32 {
+	return arena.generation
+}
+
+//export release
+func release(generation uint32) {
+	arena.release(generation)
+}
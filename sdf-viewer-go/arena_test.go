@@ -0,0 +1,42 @@
+package sdf_viewer_go
+
+import "testing"
+
+// pinnedBuffers counts the buffers pinned by the arena.
+func (a *returnArena) pinnedBuffers() int {
+	count := 0
+	for _, pinned := range a.pinned {
+		count += len(pinned.buffers)
+	}
+	return count
+}
+
+func TestArenaPinsUntilReleased(t *testing.T) {
+	a := &returnArena{}
+	for i := 0; i < 2*pinnedGenerations; i++ {
+		a.begin()
+		a.pin(i)
+	}
+	if got := a.pinnedBuffers(); got != pinnedGenerations {
+		t.Fatalf("expected the last %d generations to be pinned before the first release, got %d", pinnedGenerations, got)
+	}
+
+	// Once the host releases generations, all of the outstanding ones must stay pinned
+	a.release(a.generation)
+	first := a.generation + 1
+	for i := 0; i < 3*pinnedGenerations; i++ {
+		a.begin()
+		a.pin(i)
+	}
+	if got := a.pinnedBuffers(); got != 3*pinnedGenerations {
+		t.Fatalf("expected %d outstanding generations to be pinned, got %d", 3*pinnedGenerations, got)
+	}
+	a.release(first + pinnedGenerations - 1)
+	if got := a.pinnedBuffers(); got != 2*pinnedGenerations {
+		t.Fatalf("expected %d generations to be pinned after a partial release, got %d", 2*pinnedGenerations, got)
+	}
+	a.release(a.generation)
+	if got := a.pinnedBuffers(); got != 0 {
+		t.Fatalf("expected no pinned generations after releasing all of them, got %d", got)
+	}
+}
@@ -0,0 +1,64 @@
+// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.
+
+#ifndef SDF_VIEWER_GO_H
+#define SDF_VIEWER_GO_H
+
+#include <stdint.h>
+
+typedef struct PointerLength {
+    uint32_t pointer;
+    uint32_t length;
+} PointerLength;
+_Static_assert(sizeof(PointerLength) == 8, "PointerLength size");
+
+typedef struct SdfSample {
+    float distance;
+    float color[3];
+    float metallic;
+    float roughness;
+    float occlusion;
+} SdfSample;
+_Static_assert(sizeof(SdfSample) == 28, "SdfSample size");
+
+typedef struct SdfParamKindC {
+    uint32_t kind_id;
+    uint32_t params[3];
+} SdfParamKindC;
+_Static_assert(sizeof(SdfParamKindC) == 16, "SdfParamKindC size");
+
+typedef struct SdfParamValueC {
+    uint32_t kind_id;
+    uint32_t params[2];
+} SdfParamValueC;
+_Static_assert(sizeof(SdfParamValueC) == 12, "SdfParamValueC size");
+
+typedef struct SdfParamC {
+    uint32_t id;
+    PointerLength name;
+    SdfParamKindC kind_params;
+    SdfParamValueC value;
+    PointerLength description;
+} SdfParamC;
+_Static_assert(sizeof(SdfParamC) == 48, "SdfParamC size");
+
+typedef struct SetParameterRes {
+    uint32_t error;
+    PointerLength error_msg;
+} SetParameterRes;
+_Static_assert(sizeof(SetParameterRes) == 12, "SetParameterRes size");
+
+typedef struct ChangedAABBC {
+    uint32_t changed;
+    float aabb[2][3];
+} ChangedAABBC;
+_Static_assert(sizeof(ChangedAABBC) == 28, "ChangedAABBC size");
+
+typedef struct LastErrorC {
+    uint32_t has_error;
+    uint32_t sdf_id;
+    PointerLength export;
+    PointerLength message;
+} LastErrorC;
+_Static_assert(sizeof(LastErrorC) == 24, "LastErrorC size");
+
+#endif // SDF_VIEWER_GO_H
@@ -0,0 +1,81 @@
+// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct PointerLength {
+    pub pointer: u32,
+    pub length: u32,
+}
+
+const _: () = assert!(core::mem::size_of::<PointerLength>() == 8);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct SdfSample {
+    pub distance: f32,
+    pub color: [f32; 3],
+    pub metallic: f32,
+    pub roughness: f32,
+    pub occlusion: f32,
+}
+
+const _: () = assert!(core::mem::size_of::<SdfSample>() == 28);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct SdfParamKindC {
+    pub kind_id: u32,
+    pub params: [u32; 3],
+}
+
+const _: () = assert!(core::mem::size_of::<SdfParamKindC>() == 16);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct SdfParamValueC {
+    pub kind_id: u32,
+    pub params: [u32; 2],
+}
+
+const _: () = assert!(core::mem::size_of::<SdfParamValueC>() == 12);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct SdfParamC {
+    pub id: u32,
+    pub name: PointerLength,
+    pub kind_params: SdfParamKindC,
+    pub value: SdfParamValueC,
+    pub description: PointerLength,
+}
+
+const _: () = assert!(core::mem::size_of::<SdfParamC>() == 48);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct SetParameterRes {
+    pub error: u32,
+    pub error_msg: PointerLength,
+}
+
+const _: () = assert!(core::mem::size_of::<SetParameterRes>() == 12);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct ChangedAABBC {
+    pub changed: u32,
+    pub aabb: [[f32; 3]; 2],
+}
+
+const _: () = assert!(core::mem::size_of::<ChangedAABBC>() == 28);
+
+#[repr(C)]
+#[derive(Debug, Clone, Copy)]
+pub struct LastErrorC {
+    pub has_error: u32,
+    pub sdf_id: u32,
+    pub export: PointerLength,
+    pub message: PointerLength,
+}
+
+const _: () = assert!(core::mem::size_of::<LastErrorC>() == 24);
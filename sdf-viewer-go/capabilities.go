@@ -0,0 +1,92 @@
+package sdf_viewer_go
+
+// ABIVersion is the version of the core ABI (the exports and structs shared with the SDF Viewer app) spoken by this
+// module. It only changes on incompatible changes: new functionality is added as optional capabilities instead.
+const ABIVersion uint32 = 1
+
+// Capability is a bitset of optional functionality provided by this module, on top of the core ABI.
+// The host should only use the optional exports of the capabilities that are advertised (see `capabilities`).
+type Capability uint32
+
+const (
+	// CapabilitySampleBatch advertises the `sample_batch` export.
+	CapabilitySampleBatch Capability = 1 << iota
+	// CapabilityLastError advertises the `last_error` export, to read failures of other exports.
+	CapabilityLastError
+	// CapabilityRelease advertises the `returned_generation` and `release` exports, to unpin returned memory.
+	CapabilityRelease
+)
+
+// capabilityNames are the names of all known capabilities.
+var capabilityNames = map[Capability]string{
+	CapabilitySampleBatch: "sample_batch",
+	CapabilityLastError:   "last_error",
+	CapabilityRelease:     "release",
+}
+
+// nextCapability is the next free bit for RegisterCapability.
+var nextCapability = CapabilityRelease << 1
+
+// RegisterCapability registers a new optional capability (e.g. for exports added by other packages), which is not
+// advertised until it is enabled. It panics if all the bits of the set are taken.
+func RegisterCapability(name string) Capability {
+	c := nextCapability
+	if c == 0 {
+		panic("no free bits left to register capability " + name)
+	}
+	nextCapability <<= 1
+	capabilityNames[c] = name
+	return c
+}
+
+// enabledCapabilities are the capabilities advertised to the host.
+var enabledCapabilities = CapabilitySampleBatch | CapabilityRelease | recoveredCapabilities
+
+// EnableCapabilities advertises the given capabilities to the host.
+func EnableCapabilities(c Capability) {
+	enabledCapabilities |= c
+}
+
+// DisableCapabilities stops advertising the given capabilities to the host (e.g. to test its fallbacks).
+func DisableCapabilities(c Capability) {
+	enabledCapabilities &^= c
+}
+
+// Capabilities returns the capabilities advertised to the host.
+func Capabilities() Capability {
+	return enabledCapabilities
+}
+
+// Has returns true if all the given capabilities are set.
+func (c Capability) Has(other Capability) bool {
+	return c&other == other
+}
+
+// String returns the names of the capabilities, separated by commas.
+func (c Capability) String() string {
+	res := ""
+	for bit := Capability(1); bit != 0; bit <<= 1 {
+		if c.Has(bit) {
+			if res != "" {
+				res += ","
+			}
+			if name, ok := capabilityNames[bit]; ok {
+				res += name
+			} else {
+				res += "unknown"
+			}
+		}
+	}
+	return res
+}
+
+//export abi_version
+//goland:noinspection GoSnakeCaseUsage
+func abi_version() uint32 {
+	return ABIVersion
+}
+
+//export capabilities
+func capabilities() uint32 {
+	return uint32(enabledCapabilities)
+}
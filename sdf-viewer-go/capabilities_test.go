@@ -0,0 +1,23 @@
+package sdf_viewer_go
+
+import "testing"
+
+func TestRegisterCapabilityFull(t *testing.T) {
+	defer func(next Capability) {
+		for c := next; c != 0; c <<= 1 {
+			delete(capabilityNames, c)
+		}
+		nextCapability = next
+	}(nextCapability)
+	for c := nextCapability; c != 0; c <<= 1 {
+		if registered := RegisterCapability("test"); registered != c {
+			t.Fatalf("expected capability %d, got %d", c, registered)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic when all the bits are taken")
+		}
+	}()
+	RegisterCapability("overflow")
+}
@@ -0,0 +1,325 @@
+package sdf_viewer_go
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const cliUsage = `Usage: go run . [-set ID:PARAM=VALUE]... COMMAND [ARGS]
+
+Commands:
+  tree                      Print the hierarchy of SDFs (IDs, names and bounding boxes)
+  params [ID]               List the parameters of one SDF (or all of them)
+  sample [-d] [-id ID] [--] X Y Z
+                            Sample an SDF (the root by default) at the given point (-d: only the distance,
+                            --: needed before negative coordinates)
+  bench [-id ID] [-n N]     Benchmark sampling N random points inside the bounding box of one SDF (or all of them)
+%s
+The -set flag sets a parameter before running the command, and may be repeated.
+IDs are the same ones that the SDF Viewer app uses.
+
+To visualize the scene, build the WebAssembly module with:
+  tinygo build -o example.wasm -target wasi -opt 2 -x -no-debug .
+or, with Go 1.24+:
+  GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o example.wasm .
+and open it with the SDF Viewer app (github.com/Yeicor/sdf-viewer).
+`
+
+// RunCLI runs the command line interface of Main with the given arguments, writing the results to stdout.
+// The root SDF is registered first, unless it is nil to use the one already registered (e.g. by init).
+func RunCLI(root SDF, args []string, stdout io.Writer) error {
+	if root != nil {
+		SetRootSDF(root)
+	} else if _, ok := registry.get(rootSDFID); !ok {
+		return errors.New("no root SDF registered (see SetRootSDF)")
+	}
+
+	flags := flag.NewFlagSet("sdf-viewer-go", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	flags.Usage = func() { _, _ = fmt.Fprintf(stdout, cliUsage, cliCommandsUsage()) }
+	var sets []string
+	flags.Func("set", "set a parameter before running the command (ID:PARAM=VALUE)", func(s string) error {
+		sets = append(sets, s)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	for _, set := range sets {
+		if err := cliSetParameter(set); err != nil {
+			return err
+		}
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return nil
+	}
+	switch args[0] {
+	case "tree":
+		cliTree(stdout, rootSDFID, 0)
+		return nil
+	case "params":
+		return cliParams(stdout, args[1:])
+	case "sample":
+		return cliSample(stdout, args[1:])
+	case "bench":
+		return cliBench(stdout, args[1:])
+	case "help":
+		flags.Usage()
+		return nil
+	default:
+		if cmd, ok := cliCommands[args[0]]; ok {
+			return cmd.Run(getSDFOrPanic(rootSDFID), args[1:], stdout)
+		}
+		return errors.New("unknown command: " + args[0] + " (try help)")
+	}
+}
+
+// CLICommand is an extra command of the command line interface, provided by other packages (e.g. exporters) that
+// can't be imported from here.
+type CLICommand struct {
+	// Usage is the usage line of the command, listed by help (e.g. "mesh [-res N] FILE    Export a mesh").
+	Usage string
+	// Run runs the command with its arguments, after the -set flags are applied to the root SDF.
+	Run func(root SDF, args []string, stdout io.Writer) error
+}
+
+// cliCommands are the registered extra commands, by name.
+var cliCommands = map[string]CLICommand{}
+
+// RegisterCLICommand adds an extra command to the command line interface of Main.
+func RegisterCLICommand(name string, cmd CLICommand) {
+	cliCommands[name] = cmd
+}
+
+func cliCommandsUsage() string {
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := ""
+	for _, name := range names {
+		res += "  " + cliCommands[name].Usage + "\n"
+	}
+	return res
+}
+
+// cliChildrenIDs returns the IDs of the children of the given SDF, as the `children` export does.
+func cliChildrenIDs(sdfID uint32) []uint32 {
+	return registry.childrenIDs(sdfID, getSDFOrPanic(sdfID).Children())
+}
+
+// cliAllIDs returns the IDs of all the SDFs in the hierarchy, in depth-first order.
+func cliAllIDs(sdfID uint32) []uint32 {
+	ids := []uint32{sdfID}
+	for _, childID := range cliChildrenIDs(sdfID) {
+		ids = append(ids, cliAllIDs(childID)...)
+	}
+	return ids
+}
+
+// CLIGetSDF returns the SDF with the given ID (as listed by the tree command), for the arguments of CLI commands.
+func CLIGetSDF(idStr string) (SDF, error) {
+	_, s, err := cliGetSDF(idStr)
+	return s, err
+}
+
+func cliGetSDF(idStr string) (uint32, SDF, error) {
+	sdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid SDF ID %q: %w", idStr, err)
+	}
+	s, ok := registry.get(uint32(sdfID))
+	if !ok {
+		return 0, nil, fmt.Errorf("SDF not found: %d", sdfID)
+	}
+	return uint32(sdfID), s, nil
+}
+
+func cliTree(stdout io.Writer, sdfID uint32, depth int) {
+	s := getSDFOrPanic(sdfID)
+	aabb := s.AABB()
+	_, _ = fmt.Fprintf(stdout, "%s%d %s %v..%v\n", strings.Repeat("  ", depth), sdfID, s.Name(), aabb[0], aabb[1])
+	for _, childID := range cliChildrenIDs(sdfID) {
+		cliTree(stdout, childID, depth+1)
+	}
+}
+
+func cliParams(stdout io.Writer, args []string) error {
+	ids := cliAllIDs(rootSDFID)
+	if len(args) > 0 {
+		sdfID, _, err := cliGetSDF(args[0])
+		if err != nil {
+			return err
+		}
+		ids = []uint32{sdfID}
+	}
+	for _, sdfID := range ids {
+		s := getSDFOrPanic(sdfID)
+		params := s.Parameters()
+		if len(params) == 0 && len(args) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(stdout, "%d %s\n", sdfID, s.Name())
+		for _, param := range params {
+			_, _ = fmt.Fprintf(stdout, "  %d %s (%s) = %v", param.ID, param.Name, cliKindString(param.Kind), param.Value)
+			if param.Description != "" {
+				_, _ = fmt.Fprintf(stdout, ": %s", param.Description)
+			}
+			_, _ = fmt.Fprintln(stdout)
+		}
+	}
+	return nil
+}
+
+func cliKindString(kind SDFParamKind) string {
+	switch k := kind.(type) {
+	case SDFParamKindBool:
+		return "bool"
+	case SDFParamKindInt:
+		return fmt.Sprintf("int in [%d, %d] step %d", k.Min, k.Max, k.Step)
+	case SDFParamKindFloat:
+		return fmt.Sprintf("float in [%g, %g] step %g", k.Min, k.Max, k.Step)
+	case SDFParamKindString:
+		return "string in " + strings.Join(k.Values, "|")
+	default:
+		return fmt.Sprintf("unknown kind %T", kind)
+	}
+}
+
+// cliSetParameter parses and applies ID:PARAM=VALUE, converting the value to the kind of the parameter.
+func cliSetParameter(set string) error {
+	idStr, rest, ok1 := strings.Cut(set, ":")
+	paramStr, valueStr, ok2 := strings.Cut(rest, "=")
+	if !ok1 || !ok2 {
+		return fmt.Errorf("invalid -set %q: expected ID:PARAM=VALUE", set)
+	}
+	sdfID, s, err := cliGetSDF(idStr)
+	if err != nil {
+		return err
+	}
+	paramID, err := strconv.ParseUint(paramStr, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid parameter ID %q: %w", paramStr, err)
+	}
+	for _, param := range s.Parameters() {
+		if param.ID != uint32(paramID) {
+			continue
+		}
+		var value SDFParamValue
+		switch param.Kind.(type) {
+		case SDFParamKindBool:
+			value, err = strconv.ParseBool(valueStr)
+		case SDFParamKindInt:
+			var v int64
+			v, err = strconv.ParseInt(valueStr, 10, 32)
+			value = int32(v)
+		case SDFParamKindFloat:
+			var v float64
+			v, err = strconv.ParseFloat(valueStr, 32)
+			value = float32(v)
+		default:
+			value = valueStr
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for parameter %d of SDF %d: %w", paramID, sdfID, err)
+		}
+		if err = s.SetParameter(param.ID, value); err != nil {
+			return fmt.Errorf("setting parameter %d of SDF %d: %w", paramID, sdfID, err)
+		}
+		s.Changed() // Consume the change, as the app would
+		return nil
+	}
+	return fmt.Errorf("SDF %d has no parameter %d", sdfID, paramID)
+}
+
+func cliSample(stdout io.Writer, args []string) error {
+	flags := flag.NewFlagSet("sample", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	distanceOnly := flags.Bool("d", false, "only sample the distance")
+	idStr := flags.String("id", "0", "ID of the SDF to sample")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	_, s, err := cliGetSDF(*idStr)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 3 {
+		return errors.New("sample: expected the X Y Z coordinates of the point")
+	}
+	var point [3]float32
+	for i, arg := range flags.Args() {
+		v, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return fmt.Errorf("invalid coordinate %q: %w", arg, err)
+		}
+		point[i] = float32(v)
+	}
+	res := s.Sample(point, *distanceOnly)
+	if *distanceOnly {
+		_, _ = fmt.Fprintf(stdout, "distance=%g\n", res.Distance)
+	} else {
+		_, _ = fmt.Fprintf(stdout, "distance=%g color=%v metallic=%g roughness=%g occlusion=%g\n",
+			res.Distance, res.Color, res.Metallic, res.Roughness, res.Occlusion)
+	}
+	return nil
+}
+
+func cliBench(stdout io.Writer, args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	idStr := flags.String("id", "", "ID of the SDF to benchmark (all of them by default)")
+	n := flags.Int("n", 100000, "number of random points to sample")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *n <= 0 {
+		return errors.New("bench: the number of points must be positive")
+	}
+	ids := cliAllIDs(rootSDFID)
+	if *idStr != "" {
+		sdfID, _, err := cliGetSDF(*idStr)
+		if err != nil {
+			return err
+		}
+		ids = []uint32{sdfID}
+	}
+	for _, sdfID := range ids {
+		s := getSDFOrPanic(sdfID)
+		aabb := s.AABB()
+		rng := rand.New(rand.NewSource(int64(sdfID))) // Deterministic points
+		points := make([][3]float32, *n)
+		for i := range points {
+			for j := 0; j < 3; j++ {
+				points[i][j] = aabb[0][j] + rng.Float32()*(aabb[1][j]-aabb[0][j])
+			}
+		}
+		samples := make([]SDFSample, len(points))
+		_, _ = fmt.Fprintf(stdout, "%d %s:", sdfID, s.Name())
+		for _, distanceOnly := range []bool{true, false} {
+			start := time.Now()
+			for i, point := range points {
+				samples[i] = s.Sample(point, distanceOnly)
+			}
+			single := time.Since(start)
+			start = time.Now()
+			SampleBatch(s, points, distanceOnly, samples)
+			batch := time.Since(start)
+			_, _ = fmt.Fprintf(stdout, " distanceOnly=%v %s/sample (batch %s/sample)", distanceOnly,
+				single/time.Duration(len(points)), batch/time.Duration(len(points)))
+		}
+		_, _ = fmt.Fprintln(stdout)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build !wasm
+
+package sdf_viewer_go
+
+import (
+	"fmt"
+	"os"
+)
+
+// Main is the entry point for scenes run natively (e.g. `go run .`), to inspect and debug them with the normal Go
+// tooling instead of rebuilding the WebAssembly module. When built to WebAssembly, it does nothing, as the SDF Viewer
+// app drives the module through its exports.
+//
+// Pass a nil root to use the one already registered with SetRootSDF (usually by init), instead of building the scene
+// again.
+func Main(root SDF) {
+	if err := RunCLI(root, os.Args[1:], os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build wasm
+
+package sdf_viewer_go
+
+// Main does nothing when built to WebAssembly, as the SDF Viewer app drives the module through its exports.
+// See the native version for running scenes with `go run`.
+func Main(_ SDF) {
+}
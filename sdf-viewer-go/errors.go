@@ -0,0 +1,62 @@
+package sdf_viewer_go
+
+// exportError is a failure (panic) inside an export, which is reported to the host through `last_error` instead of
+// trapping the whole WebAssembly instance.
+type exportError struct {
+	// Export is the name of the export that failed.
+	Export string
+	// SDFID is the ID of the SDF that the export was called on.
+	SDFID uint32
+	// Message describes the failure.
+	Message string
+}
+
+// lastError is the last failure, until it is read by the host.
+var lastError *exportError
+
+// recoverExport must be deferred by all exports, to record any panic (including the ones from user code) as the last
+// error and let the export return onPanic's fallback value instead. This needs a compiler that implements recover() for
+// the target, so `last_error` is only advertised by default where it does (see recoveredCapabilities).
+func recoverExport(export string, sdfID uint32, onPanic func()) {
+	if r := recover(); r != nil {
+		lastError = &exportError{Export: export, SDFID: sdfID, Message: panicMessage(r)}
+		onPanic()
+	}
+}
+
+func panicMessage(r interface{}) string {
+	switch v := r.(type) {
+	case error:
+		return v.Error()
+	case string:
+		return v
+	default:
+		return "unknown panic" // NOTE: Printing with %v is not supported by tinygo for all types
+	}
+}
+
+type lastErrorC struct {
+	// Is there an error? If not, the other fields are empty.
+	HasError uint32 // 0 or 1
+	// The ID of the SDF that the failing export was called on.
+	SDFID uint32
+	// The name of the failing export.
+	Export pointerLength
+	// The description of the failure.
+	Message pointerLength
+}
+
+//export last_error
+//goland:noinspection GoSnakeCaseUsage
+func last_error() *lastErrorC {
+	arena.begin()
+	res := lastErrorC{}
+	if lastError != nil {
+		res.HasError = 1
+		res.SDFID = lastError.SDFID
+		res.Export = stringToPointerLength(lastError.Export)
+		res.Message = stringToPointerLength(lastError.Message)
+		lastError = nil // Reported once
+	}
+	return returnPinned(&res)
+}
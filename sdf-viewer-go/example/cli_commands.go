@@ -0,0 +1,12 @@
+//go:build !wasm
+
+package main
+
+// The commands for inspecting the scene natively are kept out of the WebAssembly module, which only needs the exports.
+import (
+	_ "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/mass"   // Adds the mass command to Main
+	_ "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/mesh"   // Adds the mesh command to Main
+	_ "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/render" // Adds the render command to Main
+	_ "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/slice"  // Adds the slice command to Main
+	_ "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/voxel"  // Adds the voxel command to Main
+)
@@ -12,10 +12,9 @@ func init() {
 	sdfviewergo.SetRootSDF(sceneSDF())
 }
 
+// main lets you inspect the scene natively (try `go run . help`), as the SDF Viewer app uses the WebAssembly exports.
 func main() {
-	fmt.Println("This is not an executable. Compile this with `" +
-		"tinygo build -o example.wasm -target wasi -opt 2 -x -no-debug ." +
-		"` and use the SDF Viewer app (github.com/Yeicor/sdf-viewer) to visualize the SDF.")
+	sdfviewergo.Main(nil) // Uses the root SDF registered by init
 }
 
 // sceneSDF returns the root SDF of the scene.
@@ -1,10 +1,58 @@
 package main
 
 import (
+	"bytes"
 	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/hostemu"
+	"strings"
 	"testing"
 )
 
 func TestScene(t *testing.T) {
 	sdfviewergo.TestImpl(t, sceneSDF())
 }
+
+func TestSceneHierarchy(t *testing.T) {
+	host := hostemu.New(sceneSDF())
+	tree := host.Tree(hostemu.RootID)
+	if tree.Name != "test-root-cube" || len(tree.Children) != 1 || tree.Children[0].Name != "test-fake-child" {
+		t.Fatalf("unexpected hierarchy: %+v", tree)
+	}
+	if len(tree.Parameters) != 1 || tree.Parameters[0].Value != float32(0.99) {
+		t.Fatalf("unexpected parameters: %+v", tree.Parameters)
+	}
+	if err := host.SetParameter(hostemu.RootID, 0, float32(0.5)); err != nil {
+		t.Fatal(err)
+	}
+	if changed := host.Changed(hostemu.RootID); !changed.Changed {
+		t.Fatal("expected a change after setting a parameter")
+	}
+	if sample := host.Sample(hostemu.RootID, [3]float32{0.75, 0, 0}, true); sample.Distance != 0.25 {
+		t.Fatalf("unexpected distance after setting a parameter: %v", sample.Distance)
+	}
+}
+
+func TestSceneCLI(t *testing.T) {
+	var out bytes.Buffer
+	if err := sdfviewergo.RunCLI(sceneSDF(), []string{"-set", "0:0=0.5", "sample", "-d", "--", "-0.75", "0", "0"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "distance=0.25\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	out.Reset()
+	if err := sdfviewergo.RunCLI(sceneSDF(), []string{"tree"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "test-root-cube") || !strings.Contains(out.String(), "  ") {
+		t.Fatalf("unexpected tree: %q", out.String())
+	}
+	tree := out.String()
+	out.Reset()
+	if err := sdfviewergo.RunCLI(nil, []string{"tree"}, &out); err != nil { // Uses the root SDF registered above
+		t.Fatal(err)
+	}
+	if out.String() != tree {
+		t.Fatalf("unexpected tree for the registered root: %q", out.String())
+	}
+}
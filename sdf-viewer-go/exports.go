@@ -1,103 +1,127 @@
 package sdf_viewer_go
 
 import (
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/abi"
 	"math"
+	"strconv"
 	"unsafe"
 )
 
-// availableSDFs is the exported SDF hierarchy implementations.
-var availableSDFs map[uint32]SDF
-var nextSDFID uint32
-
-func registerSDFAndChildren(s SDF) {
-	//fmt.Printf("registerSDFAndChildren(%d)\n", nextSDFID)
-	availableSDFs[nextSDFID] = s
-	nextSDFID++
-	for _, child := range s.Children() {
-		registerSDFAndChildren(child)
+func init() {
+	// Let other packages of this module (e.g. the host emulator) call the exports as the SDF Viewer app would.
+	abi.Registered = abi.Exports{
+		ABIVersion:   abi_version,
+		Capabilities: capabilities,
+		BoundingBox:  func(sdfID uint32) unsafe.Pointer { return unsafe.Pointer(bounding_box(sdfID)) },
+		Sample: func(sdfID uint32, point [3]float32, distanceOnly bool) unsafe.Pointer {
+			return unsafe.Pointer(sample(sdfID, point, distanceOnly))
+		},
+		SampleBatch: sample_batch,
+		Children:    func(sdfID uint32) unsafe.Pointer { return unsafe.Pointer(children(sdfID)) },
+		Name:        func(sdfID uint32) unsafe.Pointer { return unsafe.Pointer(name(sdfID)) },
+		Parameters:  func(sdfID uint32) unsafe.Pointer { return unsafe.Pointer(parameters(sdfID)) },
+		SetParameter: func(sdfID, paramID uint32, value unsafe.Pointer) unsafe.Pointer {
+			return unsafe.Pointer(setParameter(sdfID, paramID, (*sdfParamValueC)(value)))
+		},
+		Changed:            func(sdfID uint32) unsafe.Pointer { return unsafe.Pointer(changed(sdfID)) },
+		LastError:          func() unsafe.Pointer { return unsafe.Pointer(last_error()) },
+		ReturnedGeneration: returned_generation,
+		Release:            release,
 	}
 }
 
 func getSDFOrPanic(sdfID uint32) SDF {
-	if sdf, ok := availableSDFs[sdfID]; ok {
+	if sdf, ok := registry.get(sdfID); ok {
 		return sdf
 	}
-	panic("SDF not found")
+	panic("SDF not found: " + strconv.FormatUint(uint64(sdfID), 10))
 }
 
 //export bounding_box
 //goland:noinspection GoSnakeCaseUsage
-func bounding_box(sdfID uint32) *[2][3]float32 {
+func bounding_box(sdfID uint32) (res *[2][3]float32) {
+	arena.begin()
+	defer recoverExport("bounding_box", sdfID, func() { res = returnPinned(&[2][3]float32{}) })
 	//fmt.Printf("-> AABB(%d)\n", sdfID)
 	minMax := getSDFOrPanic(sdfID).AABB()
 	//fmt.Printf("<- AABB(%d) <- (%v, %v)\n", sdfID, minMax[0], minMax[1])
-	return &minMax
+	return returnPinned(&minMax)
 }
 
 //export sample
-func sample(sdfID uint32, point [3]float32, distanceOnly bool) *SDFSample {
+func sample(sdfID uint32, point [3]float32, distanceOnly bool) (res *SDFSample) {
+	arena.begin()
+	defer recoverExport("sample", sdfID, func() { res = returnPinned(&SDFSample{}) })
 	//fmt.Printf("Sample(%d, %v, %v)\n", sdfID, point, distanceOnly)
 	sample := getSDFOrPanic(sdfID).Sample(point, distanceOnly)
 	//fmt.Printf("Sample(%d, %v, %v) <- (%v)\n", sdfID, point, distanceOnly, sample)
-	return &sample
+	return returnPinned(&sample)
+}
+
+//export sample_batch
+//goland:noinspection GoSnakeCaseUsage
+func sample_batch(sdfID uint32, pointsPtr wasmPointer, count uint32, distanceOnly bool, samplesPtr wasmPointer) {
+	defer recoverExport("sample_batch", sdfID, func() {})
+	//fmt.Printf("SampleBatch(%d, %v, %v)\n", sdfID, count, distanceOnly)
+	if count == 0 {
+		return
+	}
+	// Both buffers are owned by the caller, so results are written in place without allocating
+	points := unsafe.Slice((*[3]float32)(fromWasmPointer(pointsPtr)), count)
+	samples := unsafe.Slice((*SDFSample)(fromWasmPointer(samplesPtr)), count)
+	SampleBatch(getSDFOrPanic(sdfID), points, distanceOnly, samples)
 }
 
 //export children
-func children(sdfID uint32) *pointerLength {
+func children(sdfID uint32) (res *pointerLength) {
+	arena.begin()
+	defer recoverExport("children", sdfID, func() { res = returnPinned(&pointerLength{}) })
 	//fmt.Printf("-> Children(%d)\n", sdfID)
 	children := getSDFOrPanic(sdfID).Children()
 	if len(children) == 0 {
-		res := pointerLength{Pointer: 0, Length: 0}
-		return &res
-	}
-	childrenIDs := make([]uint32, len(children))
-	for i, child := range children {
-		var childID uint32
-		var ok bool
-		for id, s := range availableSDFs { // Too slow?
-			if s == child {
-				childID = id
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			// NOTE: Children may change after a parameter update (or at any point in time),
-			// so register them again (with new IDs) if not found
-			// WARNING: A new struct instance on every Children call would cause a memory leak
-			registerSDFAndChildren(child)
-			childID = nextSDFID - 1
-		}
-		childrenIDs[i] = childID
+		registry.childrenIDs(sdfID, children)
+		return returnPinned(&pointerLength{})
 	}
-	res := pointerLength{Pointer: uintptr(unsafe.Pointer(&(childrenIDs[0]))), Length: uint32(uintptr(len(childrenIDs)) * unsafe.Sizeof(childrenIDs[0]))}
+	// NOTE: Children may change after a parameter update (or at any point in time), so unknown children are
+	// registered with new IDs, and the ones that are no longer reachable are forgotten.
+	childrenIDs := registry.childrenIDs(sdfID, children)
+	arena.pin(childrenIDs)
+	res = returnPinned(&pointerLength{Pointer: toWasmPointer(unsafe.Pointer(&(childrenIDs[0]))), Length: uint32(uintptr(len(childrenIDs)) * unsafe.Sizeof(childrenIDs[0]))})
 	//fmt.Printf("<- Children(%d) <- (%v, %v)\n", sdfID, children.Pointer, children.Length)
-	return &res
+	return res
 }
 
 //export name
-func name(sdfID uint32) *pointerLength {
+func name(sdfID uint32) (res *pointerLength) {
+	arena.begin()
+	defer recoverExport("name", sdfID, func() { res = returnPinned(&pointerLength{}) })
 	//fmt.Printf("-> Children(%d)\n", sdfID)
-	res := stringToPointerLength(getSDFOrPanic(sdfID).Name())
+	nameC := stringToPointerLength(getSDFOrPanic(sdfID).Name())
 	//fmt.Printf("<- Children(%d) <- (%v, %v)\n", sdfID, children.Pointer, children.Length)
-	return &res
+	return returnPinned(&nameC)
 }
 
 func stringToPointerLength(str string) pointerLength {
+	if len(str) == 0 {
+		return pointerLength{}
+	}
 	name := []byte(str)
-	res := pointerLength{Pointer: uintptr(unsafe.Pointer(&(name[0]))), Length: uint32(uintptr(len(name)) * unsafe.Sizeof(name[0]))}
+	arena.pin(name)
+	res := pointerLength{Pointer: toWasmPointer(unsafe.Pointer(&(name[0]))), Length: uint32(uintptr(len(name)) * unsafe.Sizeof(name[0]))}
 	return res
 }
 
 //export parameters
-func parameters(sdfID uint32) *pointerLength {
+func parameters(sdfID uint32) (res *pointerLength) {
+	arena.begin()
+	defer recoverExport("parameters", sdfID, func() { res = returnPinned(&pointerLength{}) })
 	//fmt.Printf("-> Parameters(%d)\n", sdfID)
 	params := getSDFOrPanic(sdfID).Parameters()
 	if len(params) == 0 {
-		res := pointerLength{Pointer: 0, Length: 0}
-		return &res
+		return returnPinned(&pointerLength{})
 	}
 	paramsC := make([]sdfParamC, len(params))
+	arena.pin(paramsC)
 	for i, param := range params {
 		paramsC[i] = sdfParamC{
 			ID:          param.ID,
@@ -107,56 +131,64 @@ func parameters(sdfID uint32) *pointerLength {
 			Description: stringToPointerLength(param.Description),
 		}
 	}
-	res := pointerLength{Pointer: uintptr(unsafe.Pointer(&(paramsC[0]))), Length: uint32(uintptr(len(paramsC)) * unsafe.Sizeof(paramsC[0]))}
+	res = returnPinned(&pointerLength{Pointer: toWasmPointer(unsafe.Pointer(&(paramsC[0]))), Length: uint32(uintptr(len(paramsC)) * unsafe.Sizeof(paramsC[0]))})
 	//fmt.Printf("<- Parameters(%d) <- (%v, %v)\n", sdfID, res.Pointer, res.Length)
-	return &res
+	return res
 }
 
 //export set_parameter
 //goland:noinspection GoSnakeCaseUsage
-func set_parameter(sdfID, paramID, paramKindID, paramArg1, paramArg2 uint32) *setParameterRes {
-	//fmt.Printf("-> SetParameter(%d, %d, %d, %d, %d)\n", sdfID, paramID, paramKindID, paramArg1, paramArg2)
+func set_parameter(sdfID, paramID, paramKindID uint32, paramArg1 wasmWord, paramArg2 uint32) (res *setParameterRes) {
+	value := sdfParamValueC{KindID: paramKindID, Params: [2]wasmWord{paramArg1, wasmWord(paramArg2)}}
+	return setParameter(sdfID, paramID, &value)
+}
+
+// setParameter implements set_parameter, once the flattened words of the value are back in place.
+func setParameter(sdfID, paramID uint32, value *sdfParamValueC) (res *setParameterRes) {
+	arena.begin()
+	defer recoverExport("set_parameter", sdfID, func() {
+		res = returnPinned(&setParameterRes{Error: 1, ErrorMsg: stringToPointerLength(lastError.Message)})
+	})
+	//fmt.Printf("-> SetParameter(%d, %d, %v)\n", sdfID, paramID, *value)
 	var paramVal SDFParamValue
-	switch paramKindID {
+	switch value.KindID {
 	case 0: // bool
-		paramVal = paramArg1 != 0
+		paramVal = value.Params[0] != 0
 	case 1: // int
-		paramVal = *(*int32)(unsafe.Pointer(&paramArg1))
+		paramVal = int32(uint32(value.Params[0]))
 	case 2: // float
-		paramVal = math.Float32frombits(paramArg1)
+		paramVal = math.Float32frombits(uint32(value.Params[0]))
 	case 3: // string
-		//goland:noinspection GoVetUnsafePointer
-		memPtr := unsafe.Pointer(uintptr(paramArg1))
-		memLen := paramArg2
-		var bytes = unsafe.Slice((*byte)(memPtr), memLen)
+		str := value.strings()
+		var bytes = unsafe.Slice((*byte)(fromWasmPointer(str.Pointer)), str.Length)
 		paramVal = string(bytes)
 	default:
-		panic("Invalid paramKindID")
+		panic("Invalid paramKindID: " + strconv.FormatUint(uint64(value.KindID), 10))
 	}
 	err := getSDFOrPanic(sdfID).SetParameter(paramID, paramVal)
-	res := setParameterRes{Error: 0, ErrorMsg: pointerLength{Pointer: 0, Length: 0}}
+	res = returnPinned(&setParameterRes{Error: 0, ErrorMsg: pointerLength{}})
 	//err = errors.New("testing error on set_parameter")
 	if err != nil {
-		name := []byte(err.Error())
 		res.Error = 1
-		res.ErrorMsg.Pointer = uintptr(unsafe.Pointer(&(name[0])))
-		res.ErrorMsg.Length = uint32(uintptr(len(name)) * unsafe.Sizeof(name[0]))
+		res.ErrorMsg = stringToPointerLength(err.Error())
 	}
 	//fmt.Printf("<- SetParameter(%d) <- (%v, %v)\n", sdfID, res.Pointer, res.Length)
-	return &res
+	return res
 }
 
 //export changed
-func changed(sdfID uint32) *ChangedAABB {
+func changed(sdfID uint32) (res *changedAABBC) {
+	arena.begin()
+	defer recoverExport("changed", sdfID, func() { res = returnPinned(&changedAABBC{}) })
 	//fmt.Printf("-> Changed(%d)\n", sdfID)
 	changed := getSDFOrPanic(sdfID).Changed()
-	res := changedAABBC{
+	res = returnPinned(&changedAABBC{
 		Changed: 0,
 		AABB:    changed.AABB,
-	}
+	})
 	if changed.Changed {
 		res.Changed = 1
 	}
 	//fmt.Printf("<- Changed(%d) <- (%v)\n", sdfID, Changed)
-	return &changed
+	return res
 }
@@ -0,0 +1,314 @@
+// Package hostemu plays the role of the SDF Viewer app inside the same process, for testing the exported ABI natively.
+//
+// It calls the same functions that the app calls on the WebAssembly module, and decodes the memory they return using
+// its own copy of the structs defined by the app, instead of reusing the ones of the sdf_viewer_go package.
+// This way, tests can assert on what the app would actually see.
+package hostemu
+
+import (
+	"errors"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/abi"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+// Host emulates the SDF Viewer app for the registered root SDF.
+type Host struct {
+	// ForceGC runs the garbage collector (and overwrites reclaimed memory) between each export call and the decoding of
+	// the memory it returned, to check that returned memory is kept alive until the host releases it.
+	ForceGC bool
+	exports abi.Exports
+}
+
+// New registers the root SDF (see sdfviewergo.SetRootSDF) and returns a Host to interact with it.
+func New(root sdfviewergo.SDF) *Host {
+	sdfviewergo.SetRootSDF(root)
+	return &Host{exports: abi.Registered}
+}
+
+// RootID is the ID that the app uses to access the root SDF.
+const RootID uint32 = 0
+
+// Node is a decoded node of the SDF hierarchy, as seen by the app.
+type Node struct {
+	ID         uint32
+	Name       string
+	AABB       [2][3]float32
+	Parameters []sdfviewergo.SDFParam
+	Children   []*Node
+}
+
+// Handshake calls the `abi_version` and `capabilities` exports, as the app does before using optional exports.
+func (h *Host) Handshake() (abiVersion uint32, capabilities sdfviewergo.Capability) {
+	return h.exports.ABIVersion(), sdfviewergo.Capability(h.exports.Capabilities())
+}
+
+// Tree decodes the whole hierarchy starting at the given node.
+func (h *Host) Tree(sdfID uint32) *Node {
+	node := &Node{
+		ID:         sdfID,
+		Name:       h.Name(sdfID),
+		AABB:       h.BoundingBox(sdfID),
+		Parameters: h.Parameters(sdfID),
+	}
+	for _, childID := range h.Children(sdfID) {
+		node.Children = append(node.Children, h.Tree(childID))
+	}
+	return node
+}
+
+// Find returns the first node (depth-first) of the tree with the given name, or nil.
+func (n *Node) Find(name string) *Node {
+	if n.Name == name {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// BoundingBox calls the `bounding_box` export.
+func (h *Host) BoundingBox(sdfID uint32) [2][3]float32 {
+	defer h.release()
+	return *(*[2][3]float32)(h.returned(h.exports.BoundingBox(sdfID)))
+}
+
+// Sample calls the `sample` export.
+func (h *Host) Sample(sdfID uint32, point [3]float32, distanceOnly bool) sdfviewergo.SDFSample {
+	defer h.release()
+	return *(*sdfviewergo.SDFSample)(h.returned(h.exports.Sample(sdfID, point, distanceOnly)))
+}
+
+// SampleBatch calls the `sample_batch` export.
+func (h *Host) SampleBatch(sdfID uint32, points [][3]float32, distanceOnly bool) []sdfviewergo.SDFSample {
+	samples := make([]sdfviewergo.SDFSample, len(points))
+	if len(points) == 0 {
+		return samples
+	}
+	h.exports.SampleBatch(sdfID, abi.ToPointer(unsafe.Pointer(&points[0])), uint32(len(points)), distanceOnly,
+		abi.ToPointer(unsafe.Pointer(&samples[0])))
+	return samples
+}
+
+// Children calls the `children` export, returning the IDs of the children.
+func (h *Host) Children(sdfID uint32) []uint32 {
+	defer h.release()
+	return readSlice[uint32](*(*pointerLength)(h.returned(h.exports.Children(sdfID))))
+}
+
+// Name calls the `name` export.
+func (h *Host) Name(sdfID uint32) string {
+	defer h.release()
+	return readString(*(*pointerLength)(h.returned(h.exports.Name(sdfID))))
+}
+
+// Parameters calls the `parameters` export, decoding the parameters back to Go values.
+func (h *Host) Parameters(sdfID uint32) []sdfviewergo.SDFParam {
+	defer h.release()
+	paramsC := readSlice[sdfParamC](*(*pointerLength)(h.returned(h.exports.Parameters(sdfID))))
+	params := make([]sdfviewergo.SDFParam, len(paramsC))
+	for i, paramC := range paramsC {
+		params[i] = sdfviewergo.SDFParam{
+			ID:          paramC.ID,
+			Name:        readString(paramC.Name),
+			Kind:        paramC.KindParams.decode(),
+			Value:       paramC.Value.decode(),
+			Description: readString(paramC.Description),
+		}
+	}
+	return params
+}
+
+// SetParameter calls the `set_parameter` export, encoding the value as the app would.
+func (h *Host) SetParameter(sdfID, paramID uint32, value sdfviewergo.SDFParamValue) error {
+	var valueC sdfParamValueC
+	var keepAlive []byte
+	switch v := value.(type) {
+	case bool:
+		valueC.KindID = 0
+		if v {
+			valueC.Params[0] = 1
+		}
+	case int32:
+		valueC.KindID = 1
+		valueC.Params[0] = abi.Word(uint32(v))
+	case float32:
+		valueC.KindID = 2
+		valueC.Params[0] = abi.Word(math.Float32bits(v))
+	case string:
+		valueC.KindID = 3
+		keepAlive = []byte(v)
+		if len(keepAlive) > 0 {
+			*valueC.strings() = pointerLength{Pointer: abi.ToPointer(unsafe.Pointer(&keepAlive[0])), Length: uint32(len(keepAlive))}
+		}
+	default:
+		return fmt.Errorf("unsupported parameter value type: %T", value)
+	}
+	defer h.release()
+	res := *(*setParameterRes)(h.returned(h.exports.SetParameter(sdfID, paramID, unsafe.Pointer(&valueC))))
+	runtime.KeepAlive(keepAlive)
+	if res.Error != 0 {
+		return errors.New(readString(res.ErrorMsg))
+	}
+	return nil
+}
+
+// Changed calls the `changed` export.
+func (h *Host) Changed(sdfID uint32) sdfviewergo.ChangedAABB {
+	defer h.release()
+	res := *(*changedAABBC)(h.returned(h.exports.Changed(sdfID)))
+	return sdfviewergo.ChangedAABB{Changed: res.Changed != 0, AABB: res.AABB}
+}
+
+// ExportError is a failure inside an export, as reported by the `last_error` export.
+type ExportError struct {
+	Export  string
+	SDFID   uint32
+	Message string
+}
+
+func (e *ExportError) Error() string {
+	return fmt.Sprintf("%s(%d): %s", e.Export, e.SDFID, e.Message)
+}
+
+// LastError calls the `last_error` export, returning nil if no export failed since the last call.
+func (h *Host) LastError() *ExportError {
+	defer h.release()
+	res := *(*lastErrorC)(h.returned(h.exports.LastError()))
+	if res.HasError == 0 {
+		return nil
+	}
+	return &ExportError{Export: readString(res.Export), SDFID: res.SDFID, Message: readString(res.Message)}
+}
+
+// returned is called with the memory returned by each export before decoding it.
+func (h *Host) returned(ptr unsafe.Pointer) unsafe.Pointer {
+	if h.ForceGC {
+		runtime.GC()
+		for i := 0; i < 1024; i++ { // Overwrite reclaimed memory, so that reading it does not go unnoticed
+			garbage = make([]byte, 8+i%128)
+			for j := range garbage {
+				garbage[j] = 0xff
+			}
+		}
+	}
+	return ptr
+}
+
+var garbage []byte
+
+// release lets the module reclaim all the memory returned so far, once it is decoded.
+func (h *Host) release() {
+	h.exports.Release(h.exports.ReturnedGeneration())
+}
+
+// === Copy of the structs defined by the SDF Viewer app ===
+
+type pointerLength struct {
+	Pointer abi.Pointer
+	Length  uint32 // In bytes
+}
+
+type sdfParamC struct {
+	ID          uint32
+	Name        pointerLength
+	KindParams  sdfParamKindC
+	Value       sdfParamValueC
+	Description pointerLength
+}
+
+type sdfParamKindC struct {
+	KindID uint32
+	Params [3]abi.Word
+}
+
+// strings returns the string variant of the parameters, reinterpreting the words in place.
+func (k *sdfParamKindC) strings() *pointerLength {
+	return (*pointerLength)(unsafe.Pointer(&k.Params[0]))
+}
+
+func (k sdfParamKindC) decode() sdfviewergo.SDFParamKind {
+	switch k.KindID {
+	case 0:
+		return sdfviewergo.SDFParamKindBool{}
+	case 1:
+		return sdfviewergo.SDFParamKindInt{Min: int32(uint32(k.Params[0])), Max: int32(uint32(k.Params[1])),
+			Step: int32(uint32(k.Params[2]))}
+	case 2:
+		return sdfviewergo.SDFParamKindFloat{Min: math.Float32frombits(uint32(k.Params[0])),
+			Max: math.Float32frombits(uint32(k.Params[1])), Step: math.Float32frombits(uint32(k.Params[2]))}
+	case 3:
+		valuesC := readSlice[pointerLength](*k.strings())
+		values := make([]string, len(valuesC))
+		for i, valueC := range valuesC {
+			values[i] = readString(valueC)
+		}
+		return sdfviewergo.SDFParamKindString{Values: values}
+	default:
+		panic(fmt.Sprintf("unknown parameter kind: %d", k.KindID))
+	}
+}
+
+type sdfParamValueC struct {
+	KindID uint32
+	Params [2]abi.Word
+}
+
+// strings returns the string variant of the parameters, reinterpreting the words in place.
+func (v *sdfParamValueC) strings() *pointerLength {
+	return (*pointerLength)(unsafe.Pointer(&v.Params[0]))
+}
+
+func (v sdfParamValueC) decode() sdfviewergo.SDFParamValue {
+	switch v.KindID {
+	case 0:
+		return v.Params[0] != 0
+	case 1:
+		return int32(uint32(v.Params[0]))
+	case 2:
+		return math.Float32frombits(uint32(v.Params[0]))
+	case 3:
+		return readString(*v.strings())
+	default:
+		panic(fmt.Sprintf("unknown parameter value kind: %d", v.KindID))
+	}
+}
+
+type setParameterRes struct {
+	Error    uint32
+	ErrorMsg pointerLength
+}
+
+type changedAABBC struct {
+	Changed uint32
+	AABB    [2][3]float32
+}
+
+type lastErrorC struct {
+	HasError uint32
+	SDFID    uint32
+	Export   pointerLength
+	Message  pointerLength
+}
+
+// === Memory access ===
+
+// readSlice copies the memory referenced by a pointerLength (whose length is in bytes) into a Go slice.
+func readSlice[T any](pl pointerLength) []T {
+	var zero T
+	count := uintptr(pl.Length) / unsafe.Sizeof(zero)
+	if abi.FromPointer(pl.Pointer) == nil || count == 0 {
+		return []T{}
+	}
+	return append([]T{}, unsafe.Slice((*T)(abi.FromPointer(pl.Pointer)), count)...)
+}
+
+func readString(pl pointerLength) string {
+	return string(readSlice[byte](pl))
+}
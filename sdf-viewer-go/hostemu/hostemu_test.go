@@ -0,0 +1,232 @@
+package hostemu
+
+import (
+	"errors"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"reflect"
+	"testing"
+)
+
+type testSDF struct {
+	name     string
+	children []sdfviewergo.SDF
+	params   []sdfviewergo.SDFParam
+	changed  bool
+}
+
+func (s *testSDF) AABB() [2][3]float32 {
+	return [2][3]float32{{-1, -2, -3}, {1, 2, 3}}
+}
+
+func (s *testSDF) Sample(point [3]float32, _ bool) sdfviewergo.SDFSample {
+	return sdfviewergo.SDFSample{Distance: point[0] - 0.5, Color: [3]float32{1, 0.5, 0}}
+}
+
+func (s *testSDF) Children() []sdfviewergo.SDF {
+	return s.children
+}
+
+func (s *testSDF) Name() string {
+	return s.name
+}
+
+func (s *testSDF) Parameters() []sdfviewergo.SDFParam {
+	return s.params
+}
+
+func (s *testSDF) SetParameter(paramId uint32, value sdfviewergo.SDFParamValue) error {
+	for i := range s.params {
+		if s.params[i].ID == paramId {
+			if reflect.TypeOf(s.params[i].Value) != reflect.TypeOf(value) {
+				return errors.New("wrong value type")
+			}
+			s.params[i].Value = value
+			s.changed = true
+			return nil
+		}
+	}
+	return errors.New("unknown parameter")
+}
+
+func (s *testSDF) Changed() sdfviewergo.ChangedAABB {
+	changed := s.changed
+	s.changed = false
+	return sdfviewergo.ChangedAABB{Changed: changed, AABB: s.AABB()}
+}
+
+func testScene() *testSDF {
+	return &testSDF{name: "root", params: []sdfviewergo.SDFParam{
+		{ID: 0, Name: "bool", Kind: sdfviewergo.SDFParamKindBool{}, Value: true, Description: "A bool"},
+		{ID: 1, Name: "int", Kind: sdfviewergo.SDFParamKindInt{Min: -5, Max: 5, Step: 1}, Value: int32(-3)},
+		{ID: 2, Name: "float", Kind: sdfviewergo.SDFParamKindFloat{Min: 0, Max: 1, Step: 0.1}, Value: float32(0.5)},
+		{ID: 3, Name: "string", Kind: sdfviewergo.SDFParamKindString{Values: []string{"a", "bc"}}, Value: "bc"},
+	}, children: []sdfviewergo.SDF{
+		&testSDF{name: "left"},
+		&testSDF{name: "right", children: []sdfviewergo.SDF{&testSDF{name: "leaf"}}},
+	}}
+}
+
+func TestTree(t *testing.T) {
+	scene := testScene()
+	tree := New(scene).Tree(RootID)
+	if tree.Name != "root" || len(tree.Children) != 2 {
+		t.Fatalf("unexpected root: %+v", tree)
+	}
+	if tree.Children[0].Name != "left" || tree.Children[1].Name != "right" || tree.Find("leaf") == nil {
+		t.Fatalf("unexpected children: %+v, %+v", tree.Children[0], tree.Children[1])
+	}
+	if tree.AABB != scene.AABB() {
+		t.Fatalf("unexpected AABB: %v", tree.AABB)
+	}
+	if !reflect.DeepEqual(tree.Parameters, scene.params) {
+		t.Fatalf("unexpected parameters:\n%#v\n%#v", tree.Parameters, scene.params)
+	}
+}
+
+func TestSample(t *testing.T) {
+	host := New(testScene())
+	sample := host.Sample(RootID, [3]float32{1, 0, 0}, false)
+	if sample.Distance != 0.5 || sample.Color != [3]float32{1, 0.5, 0} {
+		t.Fatalf("unexpected sample: %+v", sample)
+	}
+	samples := host.SampleBatch(RootID, [][3]float32{{1, 0, 0}, {0, 0, 0}}, true)
+	if samples[0].Distance != 0.5 || samples[1].Distance != -0.5 {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
+
+func TestSetParameter(t *testing.T) {
+	host := New(testScene())
+	if changed := host.Changed(RootID); changed.Changed {
+		t.Fatalf("unexpected change: %+v", changed)
+	}
+	for paramID, value := range []sdfviewergo.SDFParamValue{false, int32(4), float32(0.25), "a"} {
+		if err := host.SetParameter(RootID, uint32(paramID), value); err != nil {
+			t.Fatal(err)
+		}
+		if got := host.Parameters(RootID)[paramID].Value; got != value {
+			t.Fatalf("parameter %d: expected %v, got %v", paramID, value, got)
+		}
+	}
+	if changed := host.Changed(RootID); !changed.Changed || changed.AABB[1] != [3]float32{1, 2, 3} {
+		t.Fatalf("unexpected change: %+v", changed)
+	}
+	if changed := host.Changed(RootID); changed.Changed {
+		t.Fatalf("change reported twice: %+v", changed)
+	}
+	if err := host.SetParameter(RootID, 42, true); err == nil || err.Error() != "unknown parameter" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// freshChildrenSDF returns new children instances on every call, like nodes that rebuild their subtree.
+type freshChildrenSDF struct {
+	testSDF
+}
+
+func (s *freshChildrenSDF) Children() []sdfviewergo.SDF {
+	return []sdfviewergo.SDF{&testSDF{name: "fresh", children: []sdfviewergo.SDF{&testSDF{name: "fresh-leaf"}}}}
+}
+
+func TestChildrenIDsReclaimed(t *testing.T) {
+	host := New(&freshChildrenSDF{testSDF{name: "root"}})
+	var firstChildIDs, firstLeafIDs []uint32
+	for i := 0; i < 100; i++ {
+		childIDs := host.Children(RootID)
+		if len(childIDs) != 1 || host.Name(childIDs[0]) != "fresh" {
+			t.Fatalf("iteration %d: unexpected children: %v", i, childIDs)
+		}
+		leafIDs := host.Children(childIDs[0])
+		if len(leafIDs) != 1 || host.Name(leafIDs[0]) != "fresh-leaf" {
+			t.Fatalf("iteration %d: unexpected leaf: %v", i, leafIDs)
+		}
+		if i == 0 {
+			firstChildIDs, firstLeafIDs = childIDs, leafIDs
+		} else if childIDs[0] != firstChildIDs[0] || leafIDs[0] != firstLeafIDs[0] {
+			t.Fatalf("iteration %d: IDs of rebuilt nodes changed: %v -> %v, %v -> %v",
+				i, firstChildIDs, childIDs, firstLeafIDs, leafIDs)
+		}
+	}
+}
+
+func TestStableIDs(t *testing.T) {
+	before := New(testScene()).Tree(RootID)
+	scene := testScene()
+	scene.children = append(scene.children, &testSDF{name: "extra", children: []sdfviewergo.SDF{&testSDF{name: "leaf"}}})
+	after := New(scene).Tree(RootID)
+	for _, name := range []string{"left", "right", "leaf"} {
+		if before.Find(name).ID != after.Find(name).ID {
+			t.Fatalf("ID of %s changed after an unrelated change: %d -> %d", name, before.Find(name).ID, after.Find(name).ID)
+		}
+	}
+	if after.Find("extra").Children[0].ID == after.Find("right").Children[0].ID {
+		t.Fatal("nodes with the same name at different paths share an ID")
+	}
+}
+
+// panickingSDF panics when sampled or when its parameters are set, like buggy user code.
+type panickingSDF struct {
+	testSDF
+}
+
+func (s *panickingSDF) Sample(_ [3]float32, _ bool) sdfviewergo.SDFSample {
+	panic("sample failed")
+}
+
+func (s *panickingSDF) SetParameter(_ uint32, _ sdfviewergo.SDFParamValue) error {
+	panic(errors.New("set parameter failed"))
+}
+
+func TestLastError(t *testing.T) {
+	host := New(&panickingSDF{testSDF{name: "root"}})
+	if err := host.LastError(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sample := host.Sample(RootID, [3]float32{}, false); sample != (sdfviewergo.SDFSample{}) {
+		t.Fatalf("unexpected sample: %+v", sample)
+	}
+	if err := host.LastError(); err == nil || err.Export != "sample" || err.SDFID != RootID || err.Message != "sample failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := host.LastError(); err != nil {
+		t.Fatalf("error reported twice: %v", err)
+	}
+	if err := host.SetParameter(RootID, 0, true); err == nil || err.Error() != "set parameter failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := host.LastError(); err == nil || err.Export != "set_parameter" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := host.Name(1234); name != "" {
+		t.Fatalf("unexpected name: %s", name)
+	}
+	if err := host.LastError(); err == nil || err.Export != "name" || err.Message != "SDF not found: 1234" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReturnedMemoryPinned(t *testing.T) {
+	scene := testScene()
+	host := New(scene)
+	host.ForceGC = true
+	tree := host.Tree(RootID)
+	if tree.Name != "root" || tree.Find("leaf") == nil || !reflect.DeepEqual(tree.Parameters, scene.params) {
+		t.Fatalf("returned memory was reclaimed before being read: %+v", tree)
+	}
+	if err := host.SetParameter(RootID, 42, true); err == nil || err.Error() != "unknown parameter" {
+		t.Fatalf("returned memory was reclaimed before being read: %v", err)
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	host := New(testScene())
+	abiVersion, capabilities := host.Handshake()
+	if abiVersion != sdfviewergo.ABIVersion || capabilities.String() != "sample_batch,last_error,release" {
+		t.Fatalf("unexpected handshake: %d, %s", abiVersion, capabilities)
+	}
+	sdfviewergo.DisableCapabilities(sdfviewergo.CapabilitySampleBatch)
+	defer sdfviewergo.EnableCapabilities(sdfviewergo.CapabilitySampleBatch)
+	if _, capabilities = host.Handshake(); capabilities.Has(sdfviewergo.CapabilitySampleBatch) || !capabilities.Has(sdfviewergo.CapabilityLastError) {
+		t.Fatalf("unexpected capabilities: %s", capabilities)
+	}
+}
@@ -0,0 +1,29 @@
+// Package abi shares the raw functions exported to the SDF Viewer app with other packages of this module
+// (e.g. the host emulator), without making them part of the public API.
+package abi
+
+import "unsafe"
+
+// Exports are the functions exported to the host, with the same signatures and memory layout as seen by the host.
+// Returned pointers must be decoded by the caller. The only exception is SetParameter, which takes a pointer to the
+// parameter value (with the layout of the values returned by Parameters) instead of its flattened words, so that the
+// address of a string can be passed as a pointer.
+type Exports struct {
+	ABIVersion   func() uint32
+	Capabilities func() uint32
+	BoundingBox  func(sdfID uint32) unsafe.Pointer
+	Sample       func(sdfID uint32, point [3]float32, distanceOnly bool) unsafe.Pointer
+	SampleBatch  func(sdfID uint32, points Pointer, count uint32, distanceOnly bool, samples Pointer)
+	Children     func(sdfID uint32) unsafe.Pointer
+	Name         func(sdfID uint32) unsafe.Pointer
+	Parameters   func(sdfID uint32) unsafe.Pointer
+	SetParameter func(sdfID, paramID uint32, value unsafe.Pointer) unsafe.Pointer
+	Changed      func(sdfID uint32) unsafe.Pointer
+	LastError    func() unsafe.Pointer
+	// ReturnedGeneration and Release let the host unpin the memory returned by previous calls once it was read.
+	ReturnedGeneration func() uint32
+	Release            func(generation uint32)
+}
+
+// Registered is set by the sdf_viewer_go package on initialization.
+var Registered Exports
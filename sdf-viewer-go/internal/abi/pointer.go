@@ -0,0 +1,25 @@
+//go:build !wasip1 || tinygo
+
+package abi
+
+import "unsafe"
+
+// Pointer is an address in the linear memory of the WebAssembly module, as exchanged with the host.
+// TinyGo's wasm32 targets already use 32-bit pointers, and native builds (e.g. tests) need the full address, so it is a
+// real pointer: no conversion is needed and the garbage collector sees it.
+type Pointer = unsafe.Pointer
+
+// Word is an ABI word that holds either a 32-bit value or an address, depending on a kind (e.g. of a parameter).
+// It can't be a Pointer, as the garbage collector of the standard Go compiler rejects small values in pointers, so
+// addresses are read and written through an overlay struct with a Pointer field instead (as cgo does for unions).
+type Word = uintptr
+
+// ToPointer converts a Go pointer to a Pointer.
+func ToPointer(ptr unsafe.Pointer) Pointer {
+	return ptr
+}
+
+// FromPointer converts a Pointer back to a Go pointer.
+func FromPointer(ptr Pointer) unsafe.Pointer {
+	return ptr
+}
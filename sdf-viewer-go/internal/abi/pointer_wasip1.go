@@ -0,0 +1,27 @@
+//go:build wasip1 && !tinygo
+
+package abi
+
+import "unsafe"
+
+// Pointer is an address in the linear memory of the WebAssembly module, as exchanged with the host.
+// GOARCH=wasm has 64-bit pointers, but the memory (and the ABI shared with the SDF Viewer app) is 32-bit.
+type Pointer = uint32
+
+// Word is an ABI word that holds either a 32-bit value or an address, depending on a kind (e.g. of a parameter).
+type Word = uint32
+
+// ToPointer converts a Go pointer to a Pointer.
+func ToPointer(ptr unsafe.Pointer) Pointer {
+	return Pointer(uintptr(ptr))
+}
+
+// FromPointer converts a Pointer back to a Go pointer.
+//
+// The address is an offset into the linear memory, which starts at address 0 of the module, so adding it to nil
+// yields the Go pointer. This is safe because the Go runtime does not move allocations, and the host only passes
+// addresses of memory kept alive by the module: either allocated by the exported malloc (until free is called) or
+// returned by an export (until it is released).
+func FromPointer(ptr Pointer) unsafe.Pointer {
+	return unsafe.Add(nil, uintptr(ptr))
+}
@@ -0,0 +1,181 @@
+// Package abigen computes the memory layout of the structs shared with the SDF Viewer app, and generates C and Rust
+// definitions for them. It is only used by tests and `go generate`, to keep the module's binary small.
+package abigen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Wasm32WordSize is the size of a pointer word (abi.Pointer and abi.Word) in the ABI shared with the SDF Viewer app.
+const Wasm32WordSize = 4
+
+// NamedType is a struct shared with the host, with the name that the generated definitions use for it.
+type NamedType struct {
+	Name string
+	Type reflect.Type
+}
+
+// Layout is the memory layout of a struct, as computed for a given pointer word size.
+type Layout struct {
+	Name        string
+	Size, Align uintptr
+	Fields      []FieldLayout
+}
+
+// FieldLayout is the memory layout of a struct field.
+type FieldLayout struct {
+	Name         string // In snake_case, as used by the generated definitions
+	Offset, Size uintptr
+	Type         reflect.Type
+}
+
+// StructLayout computes the layout of a struct type made of 32-bit scalars, pointer words (uintptr), arrays and
+// structs, using the C rules, as if pointer words had the given size.
+func StructLayout(t NamedType, wordSize uintptr) Layout {
+	layout := Layout{Name: t.Name, Align: 1}
+	for i := 0; i < t.Type.NumField(); i++ {
+		field := t.Type.Field(i)
+		size, align := sizeAlign(field.Type, wordSize)
+		layout.Size = alignUp(layout.Size, align)
+		layout.Fields = append(layout.Fields, FieldLayout{Name: snakeCase(field.Name), Offset: layout.Size, Size: size, Type: field.Type})
+		layout.Size += size
+		if align > layout.Align {
+			layout.Align = align
+		}
+	}
+	layout.Size = alignUp(layout.Size, layout.Align)
+	return layout
+}
+
+func sizeAlign(t reflect.Type, wordSize uintptr) (size, align uintptr) {
+	switch t.Kind() {
+	case reflect.Uint32, reflect.Int32, reflect.Float32:
+		return 4, 4
+	case reflect.Uintptr, reflect.UnsafePointer:
+		return wordSize, wordSize
+	case reflect.Array:
+		size, align = sizeAlign(t.Elem(), wordSize)
+		return size * uintptr(t.Len()), align
+	case reflect.Struct:
+		layout := StructLayout(NamedType{Type: t}, wordSize)
+		return layout.Size, layout.Align
+	default:
+		panic("unsupported ABI type: " + t.String())
+	}
+}
+
+func alignUp(offset, align uintptr) uintptr {
+	return (offset + align - 1) / align * align
+}
+
+// snakeCase converts a Go field name (e.g. SDFID or KindParams) to snake_case (sdf_id or kind_params).
+func snakeCase(name string) string {
+	if strings.HasSuffix(name, "ID") && len(name) > 2 {
+		return snakeCase(strings.TrimSuffix(name, "ID")) + "_id"
+	}
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// LayoutTable describes the layout of all the given structs, one line per struct and field (for golden files).
+func LayoutTable(types []NamedType, wordSize uintptr) string {
+	var sb strings.Builder
+	for _, t := range types {
+		layout := StructLayout(t, wordSize)
+		_, _ = fmt.Fprintf(&sb, "%s size=%d align=%d\n", layout.Name, layout.Size, layout.Align)
+		for _, field := range layout.Fields {
+			_, _ = fmt.Fprintf(&sb, "%s.%s offset=%d size=%d\n", layout.Name, field.Name, field.Offset, field.Size)
+		}
+	}
+	return sb.String()
+}
+
+// GenerateC returns a C header with the definitions of all the given structs, for wasm32.
+func GenerateC(types []NamedType) string {
+	names := typeNames(types)
+	var sb strings.Builder
+	sb.WriteString("// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.\n\n")
+	sb.WriteString("#ifndef SDF_VIEWER_GO_H\n#define SDF_VIEWER_GO_H\n\n#include <stdint.h>\n\n")
+	for _, t := range types {
+		layout := StructLayout(t, Wasm32WordSize)
+		_, _ = fmt.Fprintf(&sb, "typedef struct %s {\n", layout.Name)
+		for _, field := range layout.Fields {
+			elem, dims := cType(field.Type, names)
+			_, _ = fmt.Fprintf(&sb, "    %s %s%s;\n", elem, field.Name, dims)
+		}
+		_, _ = fmt.Fprintf(&sb, "} %s;\n", layout.Name)
+		_, _ = fmt.Fprintf(&sb, "_Static_assert(sizeof(%s) == %d, \"%s size\");\n\n", layout.Name, layout.Size, layout.Name)
+	}
+	sb.WriteString("#endif // SDF_VIEWER_GO_H\n")
+	return sb.String()
+}
+
+func cType(t reflect.Type, names map[reflect.Type]string) (elem, dims string) {
+	switch t.Kind() {
+	case reflect.Uint32, reflect.Uintptr, reflect.UnsafePointer:
+		return "uint32_t", ""
+	case reflect.Int32:
+		return "int32_t", ""
+	case reflect.Float32:
+		return "float", ""
+	case reflect.Array:
+		elem, dims = cType(t.Elem(), names)
+		return elem, fmt.Sprintf("[%d]", t.Len()) + dims
+	case reflect.Struct:
+		return names[t], ""
+	default:
+		panic("unsupported ABI type: " + t.String())
+	}
+}
+
+// GenerateRust returns Rust `#[repr(C)]` definitions of all the given structs, for wasm32.
+func GenerateRust(types []NamedType) string {
+	names := typeNames(types)
+	var sb strings.Builder
+	sb.WriteString("// Code generated by sdf-viewer-go (go generate); DO NOT EDIT.\n\n")
+	for _, t := range types {
+		layout := StructLayout(t, Wasm32WordSize)
+		_, _ = fmt.Fprintf(&sb, "#[repr(C)]\n#[derive(Debug, Clone, Copy)]\npub struct %s {\n", layout.Name)
+		for _, field := range layout.Fields {
+			_, _ = fmt.Fprintf(&sb, "    pub %s: %s,\n", field.Name, rustType(field.Type, names))
+		}
+		sb.WriteString("}\n\n")
+		_, _ = fmt.Fprintf(&sb, "const _: () = assert!(core::mem::size_of::<%s>() == %d);\n\n", layout.Name, layout.Size)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func rustType(t reflect.Type, names map[reflect.Type]string) string {
+	switch t.Kind() {
+	case reflect.Uint32, reflect.Uintptr, reflect.UnsafePointer:
+		return "u32"
+	case reflect.Int32:
+		return "i32"
+	case reflect.Float32:
+		return "f32"
+	case reflect.Array:
+		return fmt.Sprintf("[%s; %d]", rustType(t.Elem(), names), t.Len())
+	case reflect.Struct:
+		return names[t]
+	default:
+		panic("unsupported ABI type: " + t.String())
+	}
+}
+
+func typeNames(types []NamedType) map[reflect.Type]string {
+	names := map[reflect.Type]string{}
+	for _, t := range types {
+		names[t.Type] = t.Name
+	}
+	return names
+}
@@ -0,0 +1,64 @@
+// Package testsdf has simple SDFs with exact distances, for the tests of the packages that consume SDFs (e.g. mesh or
+// render).
+package testsdf
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+)
+
+// Sphere is a sphere, centered at the origin unless moved along X.
+type Sphere struct {
+	Radius, X float32
+	// Material is returned by every sample, with the distance to the sphere.
+	Material sdfviewergo.SDFSample
+}
+
+func (s *Sphere) AABB() [2][3]float32 {
+	return [2][3]float32{{s.X - s.Radius, -s.Radius, -s.Radius}, {s.X + s.Radius, s.Radius, s.Radius}}
+}
+
+func (s *Sphere) Sample(p [3]float32, _ bool) sdfviewergo.SDFSample {
+	res := s.Material
+	res.Distance = float32(math.Sqrt(float64((p[0]-s.X)*(p[0]-s.X)+p[1]*p[1]+p[2]*p[2]))) - s.Radius
+	return res
+}
+
+func (s *Sphere) Children() []sdfviewergo.SDF                          { return nil }
+func (s *Sphere) Name() string                                         { return "sphere" }
+func (s *Sphere) Parameters() []sdfviewergo.SDFParam                   { return nil }
+func (s *Sphere) SetParameter(uint32, sdfviewergo.SDFParamValue) error { return nil }
+func (s *Sphere) Changed() sdfviewergo.ChangedAABB                     { return sdfviewergo.ChangedAABB{} }
+
+// Box is an axis-aligned box, centered at the origin unless moved.
+type Box struct {
+	Center, Half [3]float32
+	// Material is returned by every sample, with the distance to the box.
+	Material sdfviewergo.SDFSample
+}
+
+func (b *Box) AABB() [2][3]float32 {
+	var aabb [2][3]float32
+	for i := 0; i < 3; i++ {
+		aabb[0][i], aabb[1][i] = b.Center[i]-b.Half[i], b.Center[i]+b.Half[i]
+	}
+	return aabb
+}
+
+func (b *Box) Sample(p [3]float32, _ bool) sdfviewergo.SDFSample {
+	var outside, inside float64 = 0, -math.MaxFloat64
+	for i := 0; i < 3; i++ {
+		q := math.Abs(float64(p[i]-b.Center[i])) - float64(b.Half[i])
+		outside += math.Max(q, 0) * math.Max(q, 0)
+		inside = math.Max(inside, q)
+	}
+	res := b.Material
+	res.Distance = float32(math.Sqrt(outside) + math.Min(inside, 0))
+	return res
+}
+
+func (b *Box) Children() []sdfviewergo.SDF                          { return nil }
+func (b *Box) Name() string                                         { return "box" }
+func (b *Box) Parameters() []sdfviewergo.SDFParam                   { return nil }
+func (b *Box) SetParameter(uint32, sdfviewergo.SDFParamValue) error { return nil }
+func (b *Box) Changed() sdfviewergo.ChangedAABB                     { return sdfviewergo.ChangedAABB{} }
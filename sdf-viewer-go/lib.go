@@ -1,6 +1,7 @@
 package sdf_viewer_go
 
 import (
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/abi"
 	"math"
 	"unsafe"
 )
@@ -8,14 +9,14 @@ import (
 // SetRootSDF registers the root SDF, overriding any previous value.
 func SetRootSDF(sdf SDF) {
 	// Reset, in case this is called multiple times
-	nextSDFID = 0
-	availableSDFs = map[uint32]SDF{}
+	registry = newSDFRegistry()
 	// Also register all children, recursively.
-	registerSDFAndChildren(sdf)
+	registry.registerRoot(sdf)
 }
 
 // SDF provides access to the Signed Distance Function data. Keep in sync with the SDF Viewer app.
 // Comments may be outdated, so check the original SDF Viewer app for more details.
+// Nodes are identified by their interface value, so implementations must be comparable (usually pointers).
 type SDF interface {
 	// AABB is the bounding box of the SDF. Returns the minimum and maximum coordinates of the SDF.
 	// All operations MUST be inside this bounding box.
@@ -55,6 +56,25 @@ type SDF interface {
 	Changed() ChangedAABB
 }
 
+// BatchSampler is an optional interface for SDF implementations that can sample many points at once more efficiently
+// than calling `Sample` for each of them.
+type BatchSampler interface {
+	// SampleBatch samples the surface at all the given points, writing the results to `samples` (same length as `points`).
+	// It MUST produce the same results as calling `Sample` for each point.
+	SampleBatch(points [][3]float32, distanceOnly bool, samples []SDFSample)
+}
+
+// SampleBatch samples the SDF at all the given points into `samples`, using the BatchSampler implementation if available.
+func SampleBatch(s SDF, points [][3]float32, distanceOnly bool, samples []SDFSample) {
+	if batchSampler, ok := s.(BatchSampler); ok {
+		batchSampler.SampleBatch(points, distanceOnly, samples)
+		return
+	}
+	for i, point := range points {
+		samples[i] = s.Sample(point, distanceOnly)
+	}
+}
+
 type SDFSample struct {
 	Distance                       float32
 	Color                          [3]float32
@@ -115,11 +135,28 @@ type ChangedAABB struct {
 
 // === Private API ===
 
+// wasmPointer is an address in the linear memory of the WebAssembly module (32 bits on wasm32).
+type wasmPointer = abi.Pointer
+
+// wasmWord is an ABI word that may hold either a value or an address (32 bits on wasm32).
+// Addresses are accessed through the strings methods of the unions, which keep them as a wasmPointer.
+type wasmWord = abi.Word
+
 type pointerLength struct {
-	Pointer uintptr // Always 32 bits on wasm32
+	Pointer wasmPointer // Always 32 bits on wasm32
 	Length  uint32
 }
 
+// toWasmPointer converts a Go pointer to an address in the linear memory of the WebAssembly module.
+func toWasmPointer(ptr unsafe.Pointer) wasmPointer {
+	return abi.ToPointer(ptr)
+}
+
+// fromWasmPointer converts an address provided by the host back to a Go pointer.
+func fromWasmPointer(ptr wasmPointer) unsafe.Pointer {
+	return abi.FromPointer(ptr)
+}
+
 type sdfParamC struct {
 	ID          uint32
 	Name        pointerLength
@@ -133,7 +170,7 @@ func kindC(k SDFParamKind) sdfParamKindC {
 	case SDFParamKindBool:
 		return sdfParamKindC{
 			KindID: 0,
-			Params: [3]uint32{0, 0, 0},
+			Params: [3]wasmWord{0, 0, 0},
 		}
 	case SDFParamKindInt:
 		valMin := *(*uint32)(unsafe.Pointer(&v.Min)) // Same as math.Float32bits for int32 -> uint32
@@ -141,23 +178,28 @@ func kindC(k SDFParamKind) sdfParamKindC {
 		valStep := *(*uint32)(unsafe.Pointer(&v.Step))
 		return sdfParamKindC{
 			KindID: 1,
-			Params: [3]uint32{valMin, valMax, valStep},
+			Params: [3]wasmWord{wasmWord(valMin), wasmWord(valMax), wasmWord(valStep)},
 		}
 	case SDFParamKindFloat:
 		return sdfParamKindC{
 			KindID: 2,
-			Params: [3]uint32{math.Float32bits(v.Min), math.Float32bits(v.Max), math.Float32bits(v.Step)},
+			Params: [3]wasmWord{wasmWord(math.Float32bits(v.Min)), wasmWord(math.Float32bits(v.Max)), wasmWord(math.Float32bits(v.Step))},
 		}
 	case SDFParamKindString:
-		values := pointerLength{Pointer: 0, Length: 0}
+		values := pointerLength{}
 		if len(v.Values) > 0 {
-			values.Pointer = uintptr(unsafe.Pointer(&v.Values[0]))
-			values.Length = uint32(uintptr(len(v.Values)) * unsafe.Sizeof(v.Values[0]))
-		}
-		return sdfParamKindC{
-			KindID: 3,
-			Params: [3]uint32{uint32(values.Pointer), values.Length, 0},
+			// Go strings are not laid out as (u32, u32) pairs on every compiler, so convert them explicitly
+			valuesC := make([]pointerLength, len(v.Values))
+			arena.pin(valuesC)
+			for i, value := range v.Values {
+				valuesC[i] = stringToPointerLength(value)
+			}
+			values.Pointer = toWasmPointer(unsafe.Pointer(&valuesC[0]))
+			values.Length = uint32(uintptr(len(valuesC)) * unsafe.Sizeof(valuesC[0]))
 		}
+		res := sdfParamKindC{KindID: 3}
+		*res.strings() = values
+		return res
 	default:
 		panic("unknown kind")
 	}
@@ -165,7 +207,12 @@ func kindC(k SDFParamKind) sdfParamKindC {
 
 type sdfParamKindC struct {
 	KindID uint32
-	Params [3]uint32 // Interpretation depends on KindID (Go does not support enums with data)
+	Params [3]wasmWord // Interpretation depends on KindID (Go does not support enums with data)
+}
+
+// strings returns the string variant of the parameters (a list of strings), reinterpreting the words in place.
+func (k *sdfParamKindC) strings() *pointerLength {
+	return (*pointerLength)(unsafe.Pointer(&k.Params[0]))
 }
 
 func kindValue(v SDFParamValue) sdfParamValueC {
@@ -177,24 +224,22 @@ func kindValue(v SDFParamValue) sdfParamValueC {
 		}
 		return sdfParamValueC{
 			KindID: 0,
-			Params: [2]uint32{res, 0},
+			Params: [2]wasmWord{wasmWord(res), 0},
 		}
 	case int32:
 		return sdfParamValueC{
 			KindID: 1,
-			Params: [2]uint32{uint32(v), 0},
+			Params: [2]wasmWord{wasmWord(uint32(v)), 0},
 		}
 	case float32:
 		return sdfParamValueC{
 			KindID: 2,
-			Params: [2]uint32{math.Float32bits(v), 0},
+			Params: [2]wasmWord{wasmWord(math.Float32bits(v)), 0},
 		}
 	case string:
-		res := []byte(v)
-		return sdfParamValueC{
-			KindID: 3,
-			Params: [2]uint32{uint32(uintptr(unsafe.Pointer(&res[0]))), uint32(len(res))},
-		}
+		res := sdfParamValueC{KindID: 3}
+		*res.strings() = stringToPointerLength(v)
+		return res
 	default:
 		panic("unknown kind")
 	}
@@ -202,7 +247,12 @@ func kindValue(v SDFParamValue) sdfParamValueC {
 
 type sdfParamValueC struct {
 	KindID uint32
-	Params [2]uint32 // Interpretation depends on KindID (Go does not support enums with data)
+	Params [2]wasmWord // Interpretation depends on KindID (Go does not support enums with data)
+}
+
+// strings returns the string variant of the parameters, reinterpreting the words in place.
+func (v *sdfParamValueC) strings() *pointerLength {
+	return (*pointerLength)(unsafe.Pointer(&v.Params[0]))
 }
 
 type setParameterRes struct {
@@ -0,0 +1,43 @@
+//go:build !wasm
+
+package mass
+
+import (
+	"flag"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"strings"
+)
+
+func init() {
+	sdfviewergo.RegisterCLICommand("mass", sdfviewergo.CLICommand{
+		Usage: "mass [-res N] [-density D]\n" +
+			"                            Print the volume, area, mass, center of mass and inertia of each node",
+		Run: runCLI,
+	})
+}
+
+func runCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("mass", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	resolution := flags.Int("res", DefaultResolution, "number of the smallest cells along the longest axis")
+	density := flags.Float64("density", 1, "mass per unit of volume (e.g. 0.00124 for PLA in g/mm³)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	printProperties(stdout, Analyzer{Resolution: *resolution, Density: *density}.AnalyzeTree(root), 0)
+	return nil
+}
+
+func printProperties(stdout io.Writer, p *Properties, depth int) {
+	indent := strings.Repeat("  ", depth)
+	_, _ = fmt.Fprintf(stdout, "%s%s: volume=%.6g±%.2g area=%.6g mass=%.6g center=(%.4g, %.4g, %.4g)\n", indent, p.Name,
+		p.Volume, p.VolumeError, p.Area, p.Mass, p.CenterOfMass[0], p.CenterOfMass[1], p.CenterOfMass[2])
+	_, _ = fmt.Fprintf(stdout, "%s  inertia=[%.4g %.4g %.4g; %.4g %.4g %.4g; %.4g %.4g %.4g]\n", indent,
+		p.Inertia[0][0], p.Inertia[0][1], p.Inertia[0][2], p.Inertia[1][0], p.Inertia[1][1], p.Inertia[1][2],
+		p.Inertia[2][0], p.Inertia[2][1], p.Inertia[2][2])
+	for _, child := range p.Children {
+		printProperties(stdout, child, depth+1)
+	}
+}
@@ -0,0 +1,218 @@
+// Package mass computes physical properties of any sdfviewergo.SDF (volume, surface area, mass, center of mass and
+// inertia tensor), e.g. for weight estimates of printed parts, by integrating over its bounding box with an adaptive
+// octree.
+package mass
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+)
+
+// DefaultResolution is the number of the smallest cells along the longest axis of the bounding box used by default.
+const DefaultResolution = 256
+
+// Analyzer integrates SDFs over an adaptive octree that covers their bounding box: cells that are far from the
+// surface are integrated exactly as fully inside or outside, and the rest are subdivided down to the resolution,
+// where the surface is smoothed over the width of a cell.
+//
+// Cells are classified from the distance at their center, which assumes that the SDF does not overestimate distances
+// (and that the gradient has unit length, for the surface area).
+type Analyzer struct {
+	// Resolution is the number of the smallest cells along the longest axis of the bounding box, rounded up to a
+	// power of 2 (DefaultResolution if 0).
+	Resolution int
+	// Density is the mass per unit of volume (1 if 0), e.g. 0.00124 g/mm³ for PLA.
+	Density float64
+}
+
+// Properties are the physical properties of an SDF, assuming a uniform density.
+type Properties struct {
+	// Name is the Name of the SDF.
+	Name string
+	// Volume is the volume of the inside of the SDF.
+	Volume float64
+	// VolumeError bounds the absolute error of Volume, from the smallest cells near the surface.
+	VolumeError float64
+	// Area is the area of the surface of the SDF.
+	Area float64
+	// Mass is the volume times the density.
+	Mass float64
+	// CenterOfMass is the centroid of the inside of the SDF.
+	CenterOfMass [3]float64
+	// Inertia is the inertia tensor about the center of mass, with the density.
+	Inertia [3][3]float64
+	// Children are the properties of each child SDF, if computed by AnalyzeTree.
+	Children []*Properties
+}
+
+// Analyze computes the properties of the SDF (ignoring its children).
+func (a Analyzer) Analyze(s sdfviewergo.SDF) *Properties {
+	resolution := a.Resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	density := a.Density
+	if density <= 0 {
+		density = 1
+	}
+	aabb := s.AABB()
+	var reference [3]float64 // Moments are accumulated relative to the center, for precision
+	longest := 0.0
+	for i := 0; i < 3; i++ {
+		reference[i] = (float64(aabb[0][i]) + float64(aabb[1][i])) / 2
+		longest = math.Max(longest, float64(aabb[1][i]-aabb[0][i]))
+	}
+	res := &Properties{Name: s.Name()}
+	if longest <= 0 {
+		return res
+	}
+	// The root cell is twice the bounding box, so that the smoothed surface is not cut at its faces
+	depth := int(math.Ceil(math.Log2(float64(resolution)))) + 1
+	leafSize := 2 * longest / math.Exp2(float64(depth))
+
+	var acc accumulator
+	cells := []cell{{center: reference, size: 2 * longest}}
+	for level := 0; len(cells) > 0; level++ {
+		points := make([][3]float32, len(cells))
+		for i, c := range cells {
+			points[i] = [3]float32{float32(c.center[0]), float32(c.center[1]), float32(c.center[2])}
+		}
+		samples := make([]sdfviewergo.SDFSample, len(points))
+		sdfviewergo.SampleBatch(s, points, true, samples)
+		var next []cell
+		for i, c := range cells {
+			d := float64(samples[i].Distance)
+			reach := math.Sqrt(3) / 2 * c.size // From the center to the corners
+			switch {
+			case level == depth:
+				fill := smoothStep(d, leafSize)
+				acc.addBox(c, reference, fill)
+				acc.area += c.size * c.size * c.size * tent(d, leafSize)
+				switch { // The error of the fill, knowing that the cell is fully outside, inside or neither
+				case d >= reach:
+					acc.boundary += c.size * c.size * c.size * fill
+				case d <= -reach:
+					acc.boundary += c.size * c.size * c.size * (1 - fill)
+				default:
+					acc.boundary += c.size * c.size * c.size * math.Max(fill, 1-fill)
+				}
+			case d > reach+leafSize: // Outside
+			case d < -reach-leafSize:
+				acc.addBox(c, reference, 1)
+			default:
+				for child := 0; child < 8; child++ {
+					var center [3]float64
+					for axis := 0; axis < 3; axis++ {
+						offset := c.size / 4
+						if child&(1<<axis) == 0 {
+							offset = -offset
+						}
+						center[axis] = c.center[axis] + offset
+					}
+					next = append(next, cell{center: center, size: c.size / 2})
+				}
+			}
+		}
+		cells = next
+	}
+
+	res.Volume = acc.volume
+	res.VolumeError = acc.boundary
+	res.Area = acc.area
+	res.Mass = acc.volume * density
+	if acc.volume > 0 {
+		var com [3]float64 // Relative to the reference
+		for i := 0; i < 3; i++ {
+			com[i] = acc.first[i] / acc.volume
+			res.CenterOfMass[i] = reference[i] + com[i]
+		}
+		// Second moments about the center of mass, then the inertia tensor: I = tr(S)·Id - S
+		var second [3][3]float64
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				second[i][j] = (acc.second[i][j] - acc.volume*com[i]*com[j]) * density
+			}
+		}
+		trace := second[0][0] + second[1][1] + second[2][2]
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				res.Inertia[i][j] = -second[i][j]
+			}
+			res.Inertia[i][i] += trace
+		}
+	}
+	return res
+}
+
+// AnalyzeTree computes the properties of the SDF and of all its descendants.
+func (a Analyzer) AnalyzeTree(s sdfviewergo.SDF) *Properties {
+	return a.analyzeTree(s, map[sdfviewergo.SDF]bool{})
+}
+
+func (a Analyzer) analyzeTree(s sdfviewergo.SDF, ancestors map[sdfviewergo.SDF]bool) *Properties {
+	res := a.Analyze(s)
+	ancestors[s] = true
+	for _, child := range s.Children() {
+		if !ancestors[child] {
+			res.Children = append(res.Children, a.analyzeTree(child, ancestors))
+		}
+	}
+	delete(ancestors, s)
+	return res
+}
+
+// cell is a cubic cell of the octree.
+type cell struct {
+	center [3]float64
+	size   float64
+}
+
+// accumulator sums the integrals of the inside over the cells (with unit density), relative to a reference point.
+type accumulator struct {
+	volume   float64
+	first    [3]float64    // ∫ x dV
+	second   [3][3]float64 // ∫ x xᵀ dV
+	area     float64
+	boundary float64 // Maximum error of the volume
+}
+
+// addBox adds the given fraction of the cell, as a box of uniform density at its center.
+func (acc *accumulator) addBox(c cell, reference [3]float64, fraction float64) {
+	if fraction <= 0 {
+		return
+	}
+	volume := c.size * c.size * c.size * fraction
+	var x [3]float64
+	for i := 0; i < 3; i++ {
+		x[i] = c.center[i] - reference[i]
+	}
+	acc.volume += volume
+	for i := 0; i < 3; i++ {
+		acc.first[i] += volume * x[i]
+		for j := 0; j < 3; j++ {
+			acc.second[i][j] += volume * x[i] * x[j]
+		}
+		acc.second[i][i] += volume * c.size * c.size / 12
+	}
+}
+
+// tent is a smoothed Dirac delta of the surface, with a support of twice the cell size: its samples on a grid of that
+// size add up to exactly one along any axis.
+func tent(d, size float64) float64 {
+	return math.Max(0, 1-math.Abs(d)/size) / size
+}
+
+// smoothStep is the integral of tent: the fraction of a cell at the given distance that is inside.
+func smoothStep(d, size float64) float64 {
+	t := d / size
+	switch {
+	case t <= -1:
+		return 1
+	case t >= 1:
+		return 0
+	case t < 0:
+		return 1 - (1+t)*(1+t)/2
+	default:
+		return (1 - t) * (1 - t) / 2
+	}
+}
@@ -0,0 +1,65 @@
+package mass
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/testsdf"
+	"math"
+	"testing"
+)
+
+// tree is a box with the given children.
+type tree struct {
+	testsdf.Box
+	children []sdfviewergo.SDF
+}
+
+func (t *tree) Children() []sdfviewergo.SDF { return t.children }
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance*math.Max(math.Abs(b), 1e-9)
+}
+
+func TestSphere(t *testing.T) {
+	p := Analyzer{Resolution: 128, Density: 2}.Analyze(&testsdf.Sphere{Radius: 1})
+	volume := 4.0 / 3 * math.Pi
+	if !near(p.Volume, volume, 0.005) || math.Abs(p.Volume-volume) > p.VolumeError {
+		t.Fatalf("expected a volume of %v, got %v ± %v", volume, p.Volume, p.VolumeError)
+	}
+	if !near(p.Area, 4*math.Pi, 0.01) {
+		t.Fatalf("expected an area of %v, got %v", 4*math.Pi, p.Area)
+	}
+	if !near(p.Mass, 2*p.Volume, 1e-9) {
+		t.Fatalf("expected a mass of %v, got %v", 2*p.Volume, p.Mass)
+	}
+	for i := 0; i < 3; i++ {
+		if math.Abs(p.CenterOfMass[i]) > 1e-6 {
+			t.Fatalf("expected the center of mass at the origin, got %v", p.CenterOfMass)
+		}
+		if inertia := 0.4 * p.Mass; !near(p.Inertia[i][i], inertia, 0.01) {
+			t.Fatalf("expected a moment of inertia of %v, got %v", inertia, p.Inertia)
+		}
+	}
+}
+
+func TestBoxTree(t *testing.T) {
+	child := &testsdf.Box{Center: [3]float32{1, 0, 0.5}, Half: [3]float32{1, 2, 3}}
+	root := &tree{Box: *child, children: []sdfviewergo.SDF{child}}
+	p := Analyzer{Resolution: 64}.AnalyzeTree(root)
+	if len(p.Children) != 1 || len(p.Children[0].Children) != 0 {
+		t.Fatal("expected the properties of the child")
+	}
+	// Only the edges and corners of the box are smoothed, the faces are integrated exactly
+	m := 2.0 * 4 * 6
+	if !near(p.Volume, m, 0.005) || math.Abs(p.Volume-m) > p.VolumeError || !near(p.Area, 2*(2*4+2*6+4*6), 0.01) {
+		t.Fatalf("expected a volume of %v and an area of %v, got %v and %v", m, 2*(2*4+2*6+4*6), p.Volume, p.Area)
+	}
+	if com := p.CenterOfMass; !near(com[0], 1, 1e-4) || math.Abs(com[1]) > 1e-4 || !near(com[2], 0.5, 1e-4) {
+		t.Fatalf("expected the center of mass at the center of the box, got %v", com)
+	}
+	expected := [3]float64{m / 12 * (16 + 36), m / 12 * (4 + 36), m / 12 * (4 + 16)}
+	for i := 0; i < 3; i++ {
+		if !near(p.Inertia[i][i], expected[i], 0.01) || math.Abs(p.Inertia[i][(i+1)%3]) > 1e-3*m {
+			t.Fatalf("expected a diagonal inertia tensor of %v, got %v", expected, p.Inertia)
+		}
+	}
+}
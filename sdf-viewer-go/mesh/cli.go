@@ -0,0 +1,43 @@
+//go:build !wasm
+
+package mesh
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"time"
+)
+
+func init() {
+	sdfviewergo.RegisterCLICommand("mesh", sdfviewergo.CLICommand{
+		Usage: "mesh [-res N] [-mesher mc|dc] FILE\n" +
+			"                            Export a mesh of the scene (.stl, .obj, .ply, .glb or .3mf)",
+		Run: runCLI,
+	})
+}
+
+func runCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("mesh", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	resolution := flags.Int("res", DefaultResolution, "number of cells along the longest axis of the bounding box")
+	mesherName := flags.String("mesher", "mc", "mc (marching cubes) or dc (dual contouring, keeps sharp edges)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	mesher, err := NewMesher(*mesherName, *resolution)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("mesh: expected the output file")
+	}
+	start := time.Now()
+	if err = ExportFile(flags.Arg(0), root, mesher); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "%s (%s)\n", flags.Arg(0), time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,422 @@
+package mesh
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+)
+
+// DualContouring meshes SDFs with adaptive octree dual contouring, which keeps the sharp edges and corners (e.g. of
+// boxes and differences) that marching cubes rounds off.
+//
+// The octree is refined where the surface may cross a cell (by its distance) and the SDF is not linear inside it, so
+// that flat regions use few, large triangles. Each leaf crossed by the surface gets one vertex, placed by minimizing
+// the quadratic error function (QEF) of the tangent planes at the crossings of its edges, using the normals estimated
+// from Sample gradients.
+type DualContouring struct {
+	// Resolution is the number of the smallest cells along the longest axis of the bounding box (DefaultResolution if
+	// 0).
+	Resolution int
+	// Tolerance is the maximum deviation of the SDF from linear inside a cell for it to not be refined, relative to
+	// the size of the smallest cells (DefaultTolerance if 0).
+	Tolerance float32
+}
+
+// DefaultTolerance is the default DualContouring.Tolerance.
+const DefaultTolerance = 0.05
+
+// dcMinDepth is the minimum depth of the octree, so that small features are not missed.
+const dcMinDepth = 3
+
+// dcCell is a cell of the octree. Coordinates are in units of the smallest cells.
+type dcCell struct {
+	min      [3]int32
+	size     int32
+	children *[8]*dcCell // nil for leaves
+	corners  [8]float32  // Distances at the corners, with index x | y<<1 | z<<2
+	qef      *qef        // Of the surface crossings around the leaf, if any
+	vertex   uint32
+}
+
+// Mesh implements Mesher.
+func (dc DualContouring) Mesh(s sdfviewergo.SDF) *Mesh {
+	m := &Mesh{}
+	t := newDCTree(s.AABB(), dc.Resolution)
+	if t.cell <= 0 {
+		return m // Empty bounding box
+	}
+	tolerance := dc.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	t.build(s, tolerance*t.cell)
+
+	// Find the minimal edges crossed by the surface, each of them producing a quad around it
+	type edgeKey struct {
+		min        [3]int32
+		axis, size int32
+	}
+	type crossing struct {
+		cells      [4]*dcCell // Around the edge, counter-clockwise around its axis
+		a, b       [3]float32 // Ends of the edge (inside first)
+		da, db     float32
+		insideLast bool
+	}
+	visited := map[edgeKey]bool{}
+	var crossings []crossing
+	for _, leaf := range t.leaves {
+		for axis := int32(0); axis < 3; axis++ {
+			u, v := (axis+1)%3, (axis+2)%3
+			for e := 0; e < 4; e++ {
+				start := leaf.min
+				start[u] += int32(e&1) * leaf.size
+				start[v] += int32(e>>1) * leaf.size
+				key := edgeKey{start, axis, leaf.size}
+				c0 := int(start[0]-leaf.min[0])/int(leaf.size) | int(start[1]-leaf.min[1])/int(leaf.size)<<1 |
+					int(start[2]-leaf.min[2])/int(leaf.size)<<2
+				c1 := c0 | 1<<axis
+				d0, d1 := leaf.corners[c0], leaf.corners[c1]
+				if (d0 < 0) == (d1 < 0) || visited[key] {
+					continue
+				}
+				visited[key] = true
+				// Locate the 4 leaves around the middle of the edge (in units of half the smallest cells)
+				var cr crossing
+				minimal := true
+				for i, offset := range [4][2]int32{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+					var p [3]int32
+					p[axis] = 2*start[axis] + leaf.size
+					p[u] = 2*start[u] + offset[0]
+					p[v] = 2*start[v] + offset[1]
+					cr.cells[i] = t.locate(p)
+					if cr.cells[i] == nil || cr.cells[i].size < leaf.size {
+						minimal = false // Smaller leaves split this edge, and their edges will be used instead
+						break
+					}
+				}
+				if !minimal {
+					continue
+				}
+				cr.a, cr.b, cr.da, cr.db = t.point(start), t.point(start), d0, d1
+				cr.b[axis] += float32(leaf.size) * t.cell
+				cr.insideLast = d1 < 0
+				if cr.insideLast {
+					cr.a, cr.b, cr.da, cr.db = cr.b, cr.a, d1, d0
+				}
+				crossings = append(crossings, cr)
+			}
+		}
+	}
+
+	// Find the surface crossing of each edge (with false position) and its normal, in batches
+	points := make([][3]float32, len(crossings))
+	samples := make([]sdfviewergo.SDFSample, len(crossings))
+	for iteration := 0; iteration < 6; iteration++ {
+		for i := range crossings {
+			cr := &crossings[i]
+			k := cr.da / (cr.da - cr.db)
+			for j := 0; j < 3; j++ {
+				points[i][j] = cr.a[j] + k*(cr.b[j]-cr.a[j])
+			}
+		}
+		if iteration == 5 {
+			break
+		}
+		sdfviewergo.SampleBatch(s, points, true, samples)
+		for i := range crossings {
+			cr := &crossings[i]
+			if samples[i].Distance < 0 {
+				cr.a, cr.da = points[i], samples[i].Distance
+			} else {
+				cr.b, cr.db = points[i], samples[i].Distance
+			}
+		}
+	}
+	normals := (&Mesh{Vertices: points}).gradients(s, t.cell/16)
+	for i := range crossings {
+		for j, cell := range crossings[i].cells {
+			if j > 0 && cell == crossings[i].cells[j-1] {
+				continue // Larger leaves may appear twice
+			}
+			if cell.qef == nil {
+				cell.qef = &qef{}
+			}
+			cell.qef.add(points[i], normals[i])
+		}
+	}
+
+	// Place one vertex per leaf crossed by the surface
+	for _, leaf := range t.leaves {
+		if leaf.qef == nil {
+			continue
+		}
+		var bounds [2][3]float32
+		bounds[0] = t.point(leaf.min)
+		for i := 0; i < 3; i++ {
+			bounds[1][i] = bounds[0][i] + float32(leaf.size)*t.cell
+		}
+		leaf.vertex = uint32(len(m.Vertices))
+		m.Vertices = append(m.Vertices, leaf.qef.solve(bounds))
+	}
+
+	// Connect the vertices of the leaves around each edge
+	for _, cr := range crossings {
+		var vertices []uint32
+		for i := range cr.cells {
+			cell := cr.cells[i]
+			if cr.insideLast {
+				cell = cr.cells[3-i]
+			}
+			if len(vertices) == 0 || (vertices[len(vertices)-1] != cell.vertex && vertices[0] != cell.vertex) {
+				vertices = append(vertices, cell.vertex)
+			}
+		}
+		for i := 2; i < len(vertices); i++ {
+			m.Triangles = append(m.Triangles, [3]uint32{vertices[0], vertices[i-1], vertices[i]})
+		}
+	}
+
+	m.finishVertices(s, t.cell/4)
+	return m
+}
+
+// dcTree is the octree of DualContouring, whose root is a cube covering the bounding box with some margin.
+type dcTree struct {
+	origin   [3]float32
+	cell     float32 // Size of the smallest cells
+	maxDepth int
+	root     *dcCell
+	leaves   []*dcCell
+}
+
+func newDCTree(aabb [2][3]float32, resolution int) *dcTree {
+	g := newGrid(aabb, resolution)
+	t := &dcTree{origin: g.min, cell: g.cell}
+	if t.cell <= 0 {
+		return t
+	}
+	maxSize := 0
+	for _, size := range g.size {
+		if size > maxSize {
+			maxSize = size
+		}
+	}
+	for 1<<t.maxDepth < maxSize {
+		t.maxDepth++
+	}
+	if t.maxDepth < dcMinDepth {
+		t.maxDepth = dcMinDepth
+	}
+	t.root = &dcCell{size: 1 << t.maxDepth}
+	return t
+}
+
+// build refines the octree level by level, sampling all the cells of each level in a batch.
+func (t *dcTree) build(s sdfviewergo.SDF, tolerance float32) {
+	level := []*dcCell{t.root}
+	for depth := 0; len(level) > 0; depth++ {
+		// Sample a 3x3x3 lattice in each cell (only the corners for the smallest cells, which can't be refined)
+		steps := 3
+		if depth == t.maxDepth {
+			steps = 2
+		}
+		perCell := steps * steps * steps
+		points := make([][3]float32, 0, len(level)*perCell)
+		for _, cell := range level {
+			base := t.point(cell.min)
+			step := float32(cell.size) * t.cell / float32(steps-1)
+			for z := 0; z < steps; z++ {
+				for y := 0; y < steps; y++ {
+					for x := 0; x < steps; x++ {
+						points = append(points, [3]float32{base[0] + float32(x)*step, base[1] + float32(y)*step,
+							base[2] + float32(z)*step})
+					}
+				}
+			}
+		}
+		samples := make([]sdfviewergo.SDFSample, len(points))
+		sdfviewergo.SampleBatch(s, points, true, samples)
+
+		var next []*dcCell
+		for i, cell := range level {
+			lattice := samples[i*perCell : (i+1)*perCell]
+			at := func(x, y, z int) float32 { return lattice[(z*steps+y)*steps+x].Distance }
+			for c := 0; c < 8; c++ {
+				cell.corners[c] = at((c&1)*(steps-1), (c>>1&1)*(steps-1), (c>>2&1)*(steps-1))
+			}
+			if depth == t.maxDepth || !t.refine(cell, depth, at, tolerance) {
+				t.leaves = append(t.leaves, cell)
+				continue
+			}
+			half := cell.size / 2
+			cell.children = &[8]*dcCell{}
+			for c := range cell.children {
+				child := &dcCell{min: cell.min, size: half}
+				for axis := 0; axis < 3; axis++ {
+					child.min[axis] += int32(c>>axis&1) * half
+				}
+				cell.children[c] = child
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+}
+
+// refine decides if the cell must be subdivided, given its 3x3x3 lattice of distances.
+func (t *dcTree) refine(cell *dcCell, depth int, at func(x, y, z int) float32, tolerance float32) bool {
+	if depth < dcMinDepth {
+		return true
+	}
+	halfDiagonal := float32(cell.size) * t.cell * float32(math.Sqrt(3)) / 2
+	if float32(math.Abs(float64(at(1, 1, 1)))) > halfDiagonal {
+		return false // The surface does not cross this cell
+	}
+	// A linear SDF (e.g. a plane) matches the trilinear interpolation of the corners everywhere
+	for z := 0; z < 3; z++ {
+		for y := 0; y < 3; y++ {
+			for x := 0; x < 3; x++ {
+				var interpolated float32
+				for c := 0; c < 8; c++ {
+					weight := float32(1)
+					for axis, coord := range [3]int{x, y, z} {
+						if c>>axis&1 == 1 {
+							weight *= float32(coord) / 2
+						} else {
+							weight *= 1 - float32(coord)/2
+						}
+					}
+					interpolated += weight * cell.corners[c]
+				}
+				if float32(math.Abs(float64(at(x, y, z)-interpolated))) > tolerance {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// locate returns the leaf containing the given point (in units of half the smallest cells), or nil if outside.
+func (t *dcTree) locate(p [3]int32) *dcCell {
+	for axis := 0; axis < 3; axis++ {
+		if p[axis] <= 0 || p[axis] >= 2*t.root.size {
+			return nil
+		}
+	}
+	cell := t.root
+	for cell.children != nil {
+		c := 0
+		for axis := 0; axis < 3; axis++ {
+			if p[axis] >= 2*cell.min[axis]+cell.size {
+				c |= 1 << axis
+			}
+		}
+		cell = cell.children[c]
+	}
+	return cell
+}
+
+// point returns the position of the given corner of the smallest cells.
+func (t *dcTree) point(p [3]int32) [3]float32 {
+	return [3]float32{t.origin[0] + float32(p[0])*t.cell, t.origin[1] + float32(p[1])*t.cell,
+		t.origin[2] + float32(p[2])*t.cell}
+}
+
+// qef is a quadratic error function, the sum of the squared distances to a set of planes.
+type qef struct {
+	ata   [3][3]float64
+	atb   [3]float64
+	sum   [3]float64 // Of the points, for the mass point
+	count int
+}
+
+// add adds the plane through the point with the given normal.
+func (q *qef) add(point, normal [3]float32) {
+	dot := 0.0
+	for i := 0; i < 3; i++ {
+		dot += float64(normal[i]) * float64(point[i])
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			q.ata[i][j] += float64(normal[i]) * float64(normal[j])
+		}
+		q.atb[i] += float64(normal[i]) * dot
+		q.sum[i] += float64(point[i])
+	}
+	q.count++
+}
+
+// solve returns the point that minimizes the error, closest to the mass point when it is not unique (e.g. for flat
+// surfaces), clamped to the bounds.
+func (q *qef) solve(bounds [2][3]float32) [3]float32 {
+	var mass [3]float64
+	for i := range mass {
+		mass[i] = q.sum[i] / float64(q.count)
+	}
+	// Solve ATA * (x - mass) = ATB - ATA * mass with the pseudo-inverse, ignoring small eigenvalues
+	var rhs [3]float64
+	for i := 0; i < 3; i++ {
+		rhs[i] = q.atb[i]
+		for j := 0; j < 3; j++ {
+			rhs[i] -= q.ata[i][j] * mass[j]
+		}
+	}
+	values, vectors := symmetricEigen(q.ata)
+	maxValue := math.Max(values[0], math.Max(values[1], values[2]))
+	var res [3]float32
+	for i := 0; i < 3; i++ {
+		res[i] = float32(mass[i])
+	}
+	for k := 0; k < 3; k++ {
+		if values[k] <= 0.1*maxValue {
+			continue
+		}
+		dot := 0.0
+		for i := 0; i < 3; i++ {
+			dot += vectors[i][k] * rhs[i]
+		}
+		for i := 0; i < 3; i++ {
+			res[i] += float32(vectors[i][k] * dot / values[k])
+		}
+	}
+	for i := 0; i < 3; i++ {
+		res[i] = float32(math.Min(math.Max(float64(res[i]), float64(bounds[0][i])), float64(bounds[1][i])))
+	}
+	return res
+}
+
+// symmetricEigen returns the eigenvalues and eigenvectors (as columns) of a symmetric 3x3 matrix, using Jacobi
+// rotations.
+func symmetricEigen(a [3][3]float64) (values [3]float64, vectors [3][3]float64) {
+	vectors = [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for sweep := 0; sweep < 32; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off < 1e-20 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ { // a = a * J
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < 3; k++ { // a = J^T * a
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < 3; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p], vectors[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+	return [3]float64{a[0][0], a[1][1], a[2][2]}, vectors
+}
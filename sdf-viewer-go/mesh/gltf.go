@@ -0,0 +1,252 @@
+package mesh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"math"
+)
+
+// WriteGLB meshes the SDF and writes it as a binary glTF 2.0 (.glb) scene.
+//
+// The scene mirrors the Children() hierarchy: there is one node per SDF named by its Name(), and each leaf holds the
+// mesh of its surface (shared subtrees reuse the same mesh). Note that this means that the leaves are not combined
+// (e.g. subtracted), which is useful to work on the parts in other tools.
+//
+// The PBR channels of SDFSample are baked into vertex attributes: COLOR_0 for the base color, and the custom
+// _METALLIC, _ROUGHNESS and _OCCLUSION scalars. As core glTF materials can't read the latter, the material of each
+// leaf also uses their average values as its factors.
+func WriteGLB(w io.Writer, s sdfviewergo.SDF, mesher Mesher) error {
+	b := &gltfBuilder{meshes: map[sdfviewergo.SDF]int{}}
+	root := b.addNode(s, mesher, map[sdfviewergo.SDF]bool{})
+	b.doc.Asset = gltfAsset{Version: "2.0", Generator: "sdf-viewer-go"}
+	b.doc.Scenes = []gltfScene{{Nodes: []int{root}}}
+	if b.bin.Len() > 0 {
+		b.doc.Buffers = []gltfBuffer{{ByteLength: b.bin.Len()}}
+	}
+
+	jsonChunk, err := json.Marshal(&b.doc)
+	if err != nil {
+		return err
+	}
+	jsonChunk = pad4(jsonChunk, ' ')
+	binChunk := pad4(b.bin.Bytes(), 0)
+	length := 12 + 8 + len(jsonChunk)
+	if len(binChunk) > 0 {
+		length += 8 + len(binChunk)
+	}
+
+	var out bytes.Buffer
+	_ = binary.Write(&out, binary.LittleEndian, [3]uint32{0x46546C67, 2, uint32(length)})      // "glTF", version
+	_ = binary.Write(&out, binary.LittleEndian, [2]uint32{uint32(len(jsonChunk)), 0x4E4F534A}) // "JSON"
+	out.Write(jsonChunk)
+	if len(binChunk) > 0 {
+		_ = binary.Write(&out, binary.LittleEndian, [2]uint32{uint32(len(binChunk)), 0x004E4942}) // "BIN"
+		out.Write(binChunk)
+	}
+	_, err = out.WriteTo(w)
+	return err
+}
+
+// gltfBuilder accumulates the glTF document and its binary buffer.
+type gltfBuilder struct {
+	doc    gltfDocument
+	bin    bytes.Buffer
+	meshes map[sdfviewergo.SDF]int // glTF mesh index of each leaf already meshed
+}
+
+// addNode adds the node (and its children, recursively) for the given SDF, returning its index.
+// ancestors stops cycles in the hierarchy.
+func (b *gltfBuilder) addNode(s sdfviewergo.SDF, mesher Mesher, ancestors map[sdfviewergo.SDF]bool) int {
+	index := len(b.doc.Nodes)
+	b.doc.Nodes = append(b.doc.Nodes, gltfNode{Name: s.Name()})
+	children := s.Children()
+	if len(children) == 0 {
+		if meshIndex, ok := b.addMesh(s, mesher); ok {
+			b.doc.Nodes[index].Mesh = &meshIndex
+		}
+		return index
+	}
+	ancestors[s] = true
+	for _, child := range children {
+		if ancestors[child] {
+			continue
+		}
+		childIndex := b.addNode(child, mesher, ancestors)
+		b.doc.Nodes[index].Children = append(b.doc.Nodes[index].Children, childIndex)
+	}
+	delete(ancestors, s)
+	return index
+}
+
+// addMesh meshes the leaf SDF and adds it as a glTF mesh with its own material, returning its index if not empty.
+func (b *gltfBuilder) addMesh(s sdfviewergo.SDF, mesher Mesher) (int, bool) {
+	if meshIndex, ok := b.meshes[s]; ok {
+		return meshIndex, true
+	}
+	m := mesher.Mesh(s)
+	if len(m.Triangles) == 0 {
+		return 0, false
+	}
+
+	colors := make([][3]float32, len(m.Samples))
+	metallic := make([]float32, len(m.Samples))
+	roughness := make([]float32, len(m.Samples))
+	occlusion := make([]float32, len(m.Samples))
+	var sums [3]float64
+	for i, sample := range m.Samples {
+		for j, c := range sample.Color {
+			colors[i][j] = clamp01(c)
+		}
+		metallic[i], roughness[i], occlusion[i] = clamp01(sample.Metallic), clamp01(sample.Roughness), clamp01(sample.Occlusion)
+		sums[0] += float64(metallic[i])
+		sums[1] += float64(roughness[i])
+		sums[2] += float64(occlusion[i])
+	}
+
+	minPos, maxPos := m.Vertices[0], m.Vertices[0]
+	for _, v := range m.Vertices {
+		for i := 0; i < 3; i++ {
+			minPos[i] = float32(math.Min(float64(minPos[i]), float64(v[i])))
+			maxPos[i] = float32(math.Max(float64(maxPos[i]), float64(v[i])))
+		}
+	}
+	attributes := map[string]int{
+		"POSITION":   b.addAccessor(m.Vertices, len(m.Vertices), gltfFloat, "VEC3", gltfArrayBuffer, minPos[:], maxPos[:]),
+		"NORMAL":     b.addAccessor(m.Normals, len(m.Normals), gltfFloat, "VEC3", gltfArrayBuffer, nil, nil),
+		"COLOR_0":    b.addAccessor(colors, len(colors), gltfFloat, "VEC3", gltfArrayBuffer, nil, nil),
+		"_METALLIC":  b.addAccessor(metallic, len(metallic), gltfFloat, "SCALAR", gltfArrayBuffer, nil, nil),
+		"_ROUGHNESS": b.addAccessor(roughness, len(roughness), gltfFloat, "SCALAR", gltfArrayBuffer, nil, nil),
+		"_OCCLUSION": b.addAccessor(occlusion, len(occlusion), gltfFloat, "SCALAR", gltfArrayBuffer, nil, nil),
+	}
+	indices := b.addAccessor(m.Triangles, 3*len(m.Triangles), gltfUnsignedInt, "SCALAR", gltfElementArrayBuffer, nil, nil)
+
+	count := float64(len(m.Samples))
+	material := len(b.doc.Materials)
+	b.doc.Materials = append(b.doc.Materials, gltfMaterial{
+		Name: s.Name(),
+		PBRMetallicRoughness: gltfPBR{
+			BaseColorFactor: [4]float32{1, 1, 1, 1}, // Multiplied by COLOR_0
+			MetallicFactor:  float32(sums[0] / count),
+			RoughnessFactor: float32(sums[1] / count),
+		},
+		Extras: map[string]float32{"occlusion": float32(sums[2] / count)},
+	})
+	meshIndex := len(b.doc.Meshes)
+	b.doc.Meshes = append(b.doc.Meshes, gltfMesh{
+		Name:       s.Name(),
+		Primitives: []gltfPrimitive{{Attributes: attributes, Indices: indices, Material: material}},
+	})
+	b.meshes[s] = meshIndex
+	return meshIndex, true
+}
+
+// addAccessor appends the data (a slice of fixed-size values) to the binary buffer, with its buffer view and
+// accessor, returning the index of the accessor.
+func (b *gltfBuilder) addAccessor(data interface{}, count, componentType int, accessorType string, target int,
+	min, max []float32) int {
+	offset := b.bin.Len()
+	_ = binary.Write(&b.bin, binary.LittleEndian, data)
+	b.doc.BufferViews = append(b.doc.BufferViews, gltfBufferView{
+		ByteOffset: offset,
+		ByteLength: b.bin.Len() - offset,
+		Target:     target,
+	})
+	b.doc.Accessors = append(b.doc.Accessors, gltfAccessor{
+		BufferView:    len(b.doc.BufferViews) - 1,
+		ComponentType: componentType,
+		Count:         count,
+		Type:          accessorType,
+		Min:           min,
+		Max:           max,
+	})
+	return len(b.doc.Accessors) - 1
+}
+
+// pad4 pads the chunk to a multiple of 4 bytes, as required by GLB.
+func pad4(chunk []byte, padding byte) []byte {
+	for len(chunk)%4 != 0 {
+		chunk = append(chunk, padding)
+	}
+	return chunk
+}
+
+// === glTF 2.0 document (only the used subset) ===
+
+const (
+	gltfFloat              = 5126
+	gltfUnsignedInt        = 5125
+	gltfArrayBuffer        = 34962
+	gltfElementArrayBuffer = 34963
+)
+
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes,omitempty"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors,omitempty"`
+	BufferViews []gltfBufferView `json:"bufferViews,omitempty"`
+	Buffers     []gltfBuffer     `json:"buffers,omitempty"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name     string `json:"name"`
+	Mesh     *int   `json:"mesh,omitempty"`
+	Children []int  `json:"children,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Material   int            `json:"material"`
+}
+
+type gltfMaterial struct {
+	Name                 string             `json:"name"`
+	PBRMetallicRoughness gltfPBR            `json:"pbrMetallicRoughness"`
+	Extras               map[string]float32 `json:"extras,omitempty"`
+}
+
+type gltfPBR struct {
+	BaseColorFactor [4]float32 `json:"baseColorFactor"`
+	MetallicFactor  float32    `json:"metallicFactor"`
+	RoughnessFactor float32    `json:"roughnessFactor"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float32 `json:"min,omitempty"`
+	Max           []float32 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target"`
+}
+
+type gltfBuffer struct {
+	ByteLength int `json:"byteLength"`
+}
@@ -0,0 +1,138 @@
+package mesh
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+)
+
+// DefaultResolution is the number of cells along the longest axis of the bounding box used by default.
+const DefaultResolution = 128
+
+// MarchingCubes meshes SDFs by sampling them on a uniform grid of cubic cells that covers their bounding box.
+// Vertices are shared between neighboring cells, so closed surfaces produce watertight meshes.
+type MarchingCubes struct {
+	// Resolution is the number of cells along the longest axis of the bounding box (DefaultResolution if 0).
+	Resolution int
+}
+
+// Mesh implements Mesher.
+func (mc MarchingCubes) Mesh(s sdfviewergo.SDF) *Mesh {
+	g := newGrid(s.AABB(), mc.Resolution)
+	m := &Mesh{}
+	if g.cell <= 0 {
+		return m // Empty bounding box
+	}
+
+	// Sample two layers of the grid at a time, in batches
+	layer := func(z int) []float32 {
+		points := make([][3]float32, (g.size[0]+1)*(g.size[1]+1))
+		for y := 0; y <= g.size[1]; y++ {
+			for x := 0; x <= g.size[0]; x++ {
+				points[y*(g.size[0]+1)+x] = g.point(x, y, z)
+			}
+		}
+		samples := make([]sdfviewergo.SDFSample, len(points))
+		sdfviewergo.SampleBatch(s, points, true, samples)
+		distances := make([]float32, len(samples))
+		for i, sample := range samples {
+			distances[i] = sample.Distance
+		}
+		return distances
+	}
+	layers := [2][]float32{layer(0), nil}
+
+	edgeVertices := map[uint64]uint32{} // By grid edge, so that neighboring cells share vertices
+	for z := 0; z < g.size[2]; z++ {
+		layers[1] = layer(z + 1)
+		for y := 0; y < g.size[1]; y++ {
+			for x := 0; x < g.size[0]; x++ {
+				var values [8]float32
+				index := 0
+				for corner, offset := range mcCornerOffsets {
+					values[corner] = layers[offset[2]][(y+offset[1])*(g.size[0]+1)+x+offset[0]]
+					if values[corner] < 0 {
+						index |= 1 << corner
+					}
+				}
+				table := mcTriangleTable[index]
+				for i := 0; i+2 < len(table); i += 3 {
+					var tri [3]uint32
+					for j := 0; j < 3; j++ {
+						tri[2-j] = mc.edgeVertex(g, m, edgeVertices, [3]int{x, y, z}, int(table[i+j]), values)
+					}
+					if tri[0] != tri[1] && tri[1] != tri[2] && tri[2] != tri[0] {
+						m.Triangles = append(m.Triangles, tri)
+					}
+				}
+			}
+		}
+		layers[0] = layers[1]
+	}
+
+	m.finishVertices(s, g.cell/4)
+	return m
+}
+
+// edgeVertex returns the index of the vertex on the given edge of the cell, creating it if it is not shared with a
+// previous cell.
+func (mc MarchingCubes) edgeVertex(g grid, m *Mesh, edgeVertices map[uint64]uint32, cell [3]int, edge int,
+	values [8]float32) uint32 {
+	a, b := mcEdgeCorners[edge][0], mcEdgeCorners[edge][1]
+	var start [3]int
+	axis := 0
+	for i := 0; i < 3; i++ {
+		start[i] = cell[i] + mcCornerOffsets[a][i]
+		if mcCornerOffsets[a][i] != mcCornerOffsets[b][i] {
+			axis = i
+		}
+	}
+	key := uint64(g.index(start[0], start[1], start[2]))*3 + uint64(axis)
+	if vertex, ok := edgeVertices[key]; ok {
+		return vertex
+	}
+	// The surface crosses the edge where the linear interpolation of the distance is 0
+	t := values[a] / (values[a] - values[b])
+	position := g.point(start[0], start[1], start[2])
+	position[axis] += t * g.cell
+	vertex := uint32(len(m.Vertices))
+	m.Vertices = append(m.Vertices, position)
+	edgeVertices[key] = vertex
+	return vertex
+}
+
+// grid is a uniform grid of cubic cells covering a bounding box, with a margin of one cell so that surfaces touching
+// the bounding box are closed.
+type grid struct {
+	min  [3]float32
+	cell float32
+	size [3]int // In cells
+}
+
+func newGrid(aabb [2][3]float32, resolution int) grid {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	longest := float32(0)
+	for i := 0; i < 3; i++ {
+		longest = float32(math.Max(float64(longest), float64(aabb[1][i]-aabb[0][i])))
+	}
+	g := grid{cell: longest / float32(resolution)}
+	if g.cell <= 0 {
+		return g
+	}
+	for i := 0; i < 3; i++ {
+		g.min[i] = aabb[0][i] - g.cell
+		g.size[i] = int(math.Ceil(float64((aabb[1][i]-aabb[0][i])/g.cell))) + 2
+	}
+	return g
+}
+
+// point returns the position of the given grid point.
+func (g grid) point(x, y, z int) [3]float32 {
+	return [3]float32{g.min[0] + float32(x)*g.cell, g.min[1] + float32(y)*g.cell, g.min[2] + float32(z)*g.cell}
+}
+
+// index returns a unique index for the given grid point.
+func (g grid) index(x, y, z int) int {
+	return (z*(g.size[1]+1)+y)*(g.size[0]+1) + x
+}
@@ -0,0 +1,163 @@
+// Package mesh extracts triangle meshes from any sdfviewergo.SDF, using only its AABB and Sample methods, and writes
+// them to common 3D file formats (STL, OBJ, PLY, glTF and 3MF).
+//
+// As it does not depend on the library that built the SDF, everything that the SDF Viewer app shows (custom
+// materials, parameters set in the app...) is also exported.
+package mesh
+
+import (
+	"errors"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mesh is an indexed triangle mesh extracted from an SDF.
+type Mesh struct {
+	// Vertices are the positions of the vertices.
+	Vertices [][3]float32
+	// Normals are the unit normals of the surface at each vertex (the gradient of the SDF).
+	Normals [][3]float32
+	// Samples are the full samples of the SDF at each vertex, for colors and other material properties.
+	Samples []sdfviewergo.SDFSample
+	// Triangles are the indices of the vertices of each triangle, counter-clockwise when seen from the outside.
+	Triangles [][3]uint32
+}
+
+// Mesher extracts the surface (distance 0) of an SDF as a triangle mesh.
+type Mesher interface {
+	Mesh(s sdfviewergo.SDF) *Mesh
+}
+
+// TriangleNormal returns the unit normal of the given triangle, following the counter-clockwise winding.
+func (m *Mesh) TriangleNormal(i int) [3]float32 {
+	t := m.Triangles[i]
+	a, b, c := m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]
+	return normalize(cross(sub(b, a), sub(c, a)))
+}
+
+// Color returns the color of the given vertex, as 8-bit RGB.
+func (m *Mesh) Color(vertex int) [3]uint8 {
+	c := m.Samples[vertex].Color
+	return [3]uint8{colorByte(c[0]), colorByte(c[1]), colorByte(c[2])}
+}
+
+// finishVertices samples the SDF at the vertices of the mesh, filling the normals and samples.
+// The normals are computed with central differences of the given step.
+func (m *Mesh) finishVertices(s sdfviewergo.SDF, step float32) {
+	m.Samples = make([]sdfviewergo.SDFSample, len(m.Vertices))
+	sdfviewergo.SampleBatch(s, m.Vertices, false, m.Samples)
+	m.Normals = m.gradients(s, step)
+}
+
+// gradients returns the normalized gradients of the SDF at the vertices, computed with central differences of the
+// given step.
+func (m *Mesh) gradients(s sdfviewergo.SDF, step float32) [][3]float32 {
+	offsets := make([][3]float32, 6*len(m.Vertices))
+	for i, v := range m.Vertices {
+		for axis := 0; axis < 3; axis++ {
+			offsets[6*i+2*axis], offsets[6*i+2*axis+1] = v, v
+			offsets[6*i+2*axis][axis] += step
+			offsets[6*i+2*axis+1][axis] -= step
+		}
+	}
+	samples := make([]sdfviewergo.SDFSample, len(offsets))
+	sdfviewergo.SampleBatch(s, offsets, true, samples)
+	res := make([][3]float32, len(m.Vertices))
+	for i := range res {
+		for axis := 0; axis < 3; axis++ {
+			res[i][axis] = samples[6*i+2*axis].Distance - samples[6*i+2*axis+1].Distance
+		}
+		res[i] = normalize(res[i])
+	}
+	return res
+}
+
+// NewMesher returns the Mesher with the given name ("mc" for MarchingCubes or "dc" for DualContouring), at the given
+// resolution (see their Resolution).
+func NewMesher(name string, resolution int) (Mesher, error) {
+	switch name {
+	case "mc":
+		return MarchingCubes{Resolution: resolution}, nil
+	case "dc":
+		return DualContouring{Resolution: resolution}, nil
+	default:
+		return nil, errors.New("unknown mesher: " + name + " (expected mc or dc)")
+	}
+}
+
+// writers are the supported file formats for single meshes, by extension.
+var writers = map[string]func(w io.Writer, m *Mesh) error{
+	".stl": WriteSTL,
+	".obj": WriteOBJ,
+	".ply": WritePLY,
+}
+
+// sceneWriters are the supported file formats that keep the hierarchy of the SDF, by extension.
+var sceneWriters = map[string]func(w io.Writer, s sdfviewergo.SDF, mesher Mesher) error{
+	".glb": WriteGLB,
+	".3mf": Write3MF,
+}
+
+// WriteFile writes the mesh to the given path, in the format given by its extension (e.g. ".stl").
+func WriteFile(path string, m *Mesh) error {
+	writer, ok := writers[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return errors.New("unsupported mesh format: " + path)
+	}
+	return createFile(path, func(w io.Writer) error { return writer(w, m) })
+}
+
+// ExportFile meshes the SDF with the given Mesher and writes it to the given path, in the format given by its
+// extension. Formats that support it (e.g. ".glb") keep the hierarchy of the SDF.
+func ExportFile(path string, s sdfviewergo.SDF, mesher Mesher) error {
+	if writer, ok := sceneWriters[strings.ToLower(filepath.Ext(path))]; ok {
+		return createFile(path, func(w io.Writer) error { return writer(w, s, mesher) })
+	}
+	if _, ok := writers[strings.ToLower(filepath.Ext(path))]; !ok {
+		return errors.New("unsupported mesh format: " + path) // Before meshing, which may be slow
+	}
+	return WriteFile(path, mesher.Mesh(s))
+}
+
+func createFile(path string, write func(w io.Writer) error) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return write(f)
+}
+
+// === Vector helpers ===
+
+func sub(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func cross(a, b [3]float32) [3]float32 {
+	return [3]float32{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func normalize(a [3]float32) [3]float32 {
+	length := float32(math.Sqrt(float64(a[0]*a[0] + a[1]*a[1] + a[2]*a[2])))
+	if length == 0 {
+		return a
+	}
+	return [3]float32{a[0] / length, a[1] / length, a[2] / length}
+}
+
+func colorByte(c float32) uint8 {
+	return uint8(math.Round(float64(clamp01(c)) * 255))
+}
+
+func clamp01(v float32) float32 {
+	return float32(math.Min(math.Max(float64(v), 0), 1))
+}
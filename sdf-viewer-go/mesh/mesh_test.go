@@ -0,0 +1,243 @@
+package mesh
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/testsdf"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// red is the material of the test spheres.
+var red = sdfviewergo.SDFSample{Color: [3]float32{1, 0, 0}, Metallic: 0.25, Roughness: 0.5}
+
+// union is the union of its children.
+type union struct {
+	testsdf.Sphere // Only for the unused methods
+	children       []sdfviewergo.SDF
+}
+
+func (u *union) AABB() [2][3]float32 {
+	aabb := u.children[0].AABB()
+	for _, child := range u.children[1:] {
+		childAABB := child.AABB()
+		for i := 0; i < 3; i++ {
+			aabb[0][i] = float32(math.Min(float64(aabb[0][i]), float64(childAABB[0][i])))
+			aabb[1][i] = float32(math.Max(float64(aabb[1][i]), float64(childAABB[1][i])))
+		}
+	}
+	return aabb
+}
+
+func (u *union) Sample(p [3]float32, distanceOnly bool) sdfviewergo.SDFSample {
+	res := u.children[0].Sample(p, distanceOnly)
+	for _, child := range u.children[1:] {
+		if sample := child.Sample(p, distanceOnly); sample.Distance < res.Distance {
+			res = sample
+		}
+	}
+	return res
+}
+
+func (u *union) Children() []sdfviewergo.SDF { return u.children }
+func (u *union) Name() string                { return "union" }
+
+// signedVolume returns the volume enclosed by the mesh, which is positive if the triangles face outwards.
+func signedVolume(m *Mesh) float64 {
+	volume := 0.0
+	for _, tri := range m.Triangles {
+		a, b, c := m.Vertices[tri[0]], m.Vertices[tri[1]], m.Vertices[tri[2]]
+		n := cross(b, c)
+		volume += float64(a[0]*n[0]+a[1]*n[1]+a[2]*n[2]) / 6
+	}
+	return volume
+}
+
+// checkWatertight checks that each edge is shared by exactly two triangles, in opposite directions.
+func checkWatertight(t *testing.T, m *Mesh) {
+	t.Helper()
+	edges := map[[2]uint32]int{}
+	for _, tri := range m.Triangles {
+		for i := 0; i < 3; i++ {
+			edges[[2]uint32{tri[i], tri[(i+1)%3]}]++
+		}
+	}
+	for edge, count := range edges {
+		if count != 1 || edges[[2]uint32{edge[1], edge[0]}] != 1 {
+			t.Fatalf("edge %v is not shared by exactly two consistently oriented triangles", edge)
+		}
+	}
+}
+
+func TestMarchingCubes(t *testing.T) {
+	m := MarchingCubes{Resolution: 32}.Mesh(&testsdf.Sphere{Radius: 1, Material: red})
+	if len(m.Triangles) == 0 {
+		t.Fatal("empty mesh")
+	}
+	checkWatertight(t, m)
+	if volume := signedVolume(m); math.Abs(volume-4*math.Pi/3) > 0.03*4*math.Pi/3 {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+	for i, v := range m.Vertices {
+		n := m.Normals[i]
+		if dot := v[0]*n[0] + v[1]*n[1] + v[2]*n[2]; dot < 0.99 { // Both are unit vectors pointing outwards
+			t.Fatalf("unexpected normal %v at %v", n, v)
+		}
+		if m.Color(i) != [3]uint8{255, 0, 0} {
+			t.Fatalf("unexpected color %v at %v", m.Color(i), v)
+		}
+	}
+}
+
+func TestWriters(t *testing.T) {
+	m := MarchingCubes{Resolution: 8}.Mesh(&testsdf.Sphere{Radius: 1, Material: red})
+	var buf bytes.Buffer
+	if err := WriteSTL(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 84+50*len(m.Triangles) {
+		t.Fatalf("unexpected STL size: %d", buf.Len())
+	}
+
+	buf.Reset()
+	if err := WriteOBJ(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	obj := buf.String()
+	if strings.Count(obj, "\nv ") != len(m.Vertices) || strings.Count(obj, "\nf ") != len(m.Triangles) ||
+		!strings.Contains(obj, " 1 0 0\n") {
+		t.Fatalf("unexpected OBJ:\n%s", obj)
+	}
+
+	buf.Reset()
+	if err := WritePLY(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	header, body, ok := bytes.Cut(buf.Bytes(), []byte("end_header\n"))
+	if !ok || len(body) != 27*len(m.Vertices)+13*len(m.Triangles) {
+		t.Fatalf("unexpected PLY size: %d", len(body))
+	}
+	if !bytes.Contains(header, []byte("element face "+strconv.Itoa(len(m.Triangles)))) {
+		t.Fatalf("unexpected PLY header:\n%s", header)
+	}
+	if body[24] != 255 || body[25] != 0 {
+		t.Fatalf("unexpected PLY color: %v", body[24:27])
+	}
+}
+
+func TestWriteGLB(t *testing.T) {
+	shared := &testsdf.Sphere{Radius: 1, X: 2, Material: red}
+	root := &union{children: []sdfviewergo.SDF{&testsdf.Sphere{Radius: 1, Material: red}, &union{children: []sdfviewergo.SDF{shared}}, shared}}
+	var buf bytes.Buffer
+	if err := WriteGLB(&buf, root, MarchingCubes{Resolution: 8}); err != nil {
+		t.Fatal(err)
+	}
+	glb := buf.Bytes()
+	if string(glb[:4]) != "glTF" || int(binary.LittleEndian.Uint32(glb[8:])) != len(glb) {
+		t.Fatalf("invalid GLB header: %v", glb[:12])
+	}
+	jsonLength := binary.LittleEndian.Uint32(glb[12:])
+	var doc gltfDocument
+	if err := json.Unmarshal(glb[20:20+jsonLength], &doc); err != nil {
+		t.Fatal(err)
+	}
+	binLength := int(binary.LittleEndian.Uint32(glb[20+jsonLength:]))
+	if len(doc.Buffers) != 1 || doc.Buffers[0].ByteLength > binLength || 28+int(jsonLength)+binLength != len(glb) {
+		t.Fatalf("invalid GLB chunks: %+v", doc.Buffers)
+	}
+
+	names := ""
+	for _, node := range doc.Nodes {
+		names += node.Name + " "
+	}
+	if names != "union sphere union sphere sphere " || len(doc.Nodes[0].Children) != 3 || len(doc.Nodes[2].Children) != 1 {
+		t.Fatalf("unexpected nodes: %+v", doc.Nodes)
+	}
+	if len(doc.Meshes) != 2 || *doc.Nodes[3].Mesh != *doc.Nodes[4].Mesh || doc.Nodes[0].Mesh != nil {
+		t.Fatalf("expected one mesh per leaf, shared between nodes: %+v", doc.Nodes)
+	}
+	material := doc.Materials[0].PBRMetallicRoughness
+	if material.MetallicFactor != 0.25 || material.RoughnessFactor != 0.5 {
+		t.Fatalf("unexpected material: %+v", material)
+	}
+	for name, accessor := range doc.Meshes[0].Primitives[0].Attributes {
+		if doc.Accessors[accessor].Count != doc.Accessors[doc.Meshes[0].Primitives[0].Attributes["POSITION"]].Count {
+			t.Fatalf("unexpected count for attribute %s", name)
+		}
+	}
+}
+
+func TestWrite3MF(t *testing.T) {
+	root := &union{children: []sdfviewergo.SDF{&testsdf.Sphere{Radius: 1, Material: red}, &testsdf.Sphere{Radius: 1, X: 3, Material: red}}}
+	var buf bytes.Buffer
+	if err := Write3MF(&buf, root, MarchingCubes{Resolution: 8}); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := archive.Open("3D/3dmodel.model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var model struct {
+		Materials []struct {
+			Name  string `xml:"name,attr"`
+			Color string `xml:"displaycolor,attr"`
+		} `xml:"resources>basematerials>base"`
+		Objects []struct {
+			Name      string     `xml:"name,attr"`
+			Vertices  []struct{} `xml:"mesh>vertices>vertex"`
+			Triangles []struct {
+				V1 int `xml:"v1,attr"`
+			} `xml:"mesh>triangles>triangle"`
+		} `xml:"resources>object"`
+		Items []struct{} `xml:"build>item"`
+	}
+	if err = xml.NewDecoder(f).Decode(&model); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Objects) != 2 || len(model.Items) != 2 || len(model.Materials) != 2 {
+		t.Fatalf("expected one object per top-level child: %+v", model)
+	}
+	for i, object := range model.Objects {
+		if object.Name != "sphere" || model.Materials[i].Color != "#FF0000FF" || len(object.Triangles) == 0 ||
+			len(object.Vertices) == 0 {
+			t.Fatalf("unexpected object %d: %s %s", i, object.Name, model.Materials[i].Color)
+		}
+	}
+}
+
+func TestDualContouring(t *testing.T) {
+	m := DualContouring{Resolution: 32}.Mesh(&testsdf.Box{Half: [3]float32{1, 1, 1}})
+	checkWatertight(t, m)
+	if volume := signedVolume(m); math.Abs(volume-8) > 0.01 {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+	for corner := 0; corner < 8; corner++ { // Sharp corners are kept
+		found := false
+		for _, v := range m.Vertices {
+			found = found || math.Abs(float64(v[0])-float64(corner&1*2-1))+math.Abs(float64(v[1])-float64(corner>>1&1*2-1))+
+				math.Abs(float64(v[2])-float64(corner>>2&1*2-1)) < 1e-3
+		}
+		if !found {
+			t.Fatalf("missing vertex at corner %d", corner)
+		}
+	}
+	if mc := (MarchingCubes{Resolution: 32}).Mesh(&testsdf.Box{Half: [3]float32{1, 1, 1}}); len(m.Triangles)*4 > len(mc.Triangles) {
+		t.Fatalf("flat faces should use fewer triangles: %d vs %d with marching cubes", len(m.Triangles), len(mc.Triangles))
+	}
+
+	m = DualContouring{Resolution: 32}.Mesh(&testsdf.Sphere{Radius: 1, Material: red})
+	checkWatertight(t, m)
+	if volume := signedVolume(m); math.Abs(volume-4*math.Pi/3) > 0.03*4*math.Pi/3 {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+}
@@ -0,0 +1,28 @@
+package mesh
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteOBJ writes the mesh in the Wavefront OBJ format, with vertex normals. Vertex colors are written after the
+// positions (`v x y z r g b`), an extension understood by most tools (e.g. Blender and MeshLab).
+func WriteOBJ(w io.Writer, m *Mesh) error {
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintln(bw, "# sdf-viewer-go")
+	for i, v := range m.Vertices {
+		c := m.Samples[i].Color
+		_, _ = fmt.Fprintf(bw, "v %g %g %g %.4g %.4g %.4g\n", v[0], v[1], v[2], clamp01(c[0]), clamp01(c[1]), clamp01(c[2]))
+	}
+	for _, n := range m.Normals {
+		_, _ = fmt.Fprintf(bw, "vn %g %g %g\n", n[0], n[1], n[2])
+	}
+	for _, tri := range m.Triangles { // Indices start at 1
+		if _, err := fmt.Fprintf(bw, "f %d//%d %d//%d %d//%d\n", tri[0]+1, tri[0]+1, tri[1]+1, tri[1]+1,
+			tri[2]+1, tri[2]+1); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+package mesh
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WritePLY writes the mesh in the binary (little endian) PLY format, with vertex normals and colors.
+func WritePLY(w io.Writer, m *Mesh) error {
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, `ply
+format binary_little_endian 1.0
+comment sdf-viewer-go
+element vertex %d
+property float x
+property float y
+property float z
+property float nx
+property float ny
+property float nz
+property uchar red
+property uchar green
+property uchar blue
+element face %d
+property list uchar uint vertex_indices
+end_header
+`, len(m.Vertices), len(m.Triangles))
+	var vertexBuf [27]byte // 6 floats and 3 bytes
+	for i, v := range m.Vertices {
+		for j, f := range append(v[:], m.Normals[i][:]...) {
+			binary.LittleEndian.PutUint32(vertexBuf[4*j:], math.Float32bits(f))
+		}
+		color := m.Color(i)
+		copy(vertexBuf[24:], color[:])
+		if _, err := bw.Write(vertexBuf[:]); err != nil {
+			return err
+		}
+	}
+	var faceBuf [13]byte // Count and 3 indices
+	faceBuf[0] = 3
+	for _, tri := range m.Triangles {
+		for j, index := range tri {
+			binary.LittleEndian.PutUint32(faceBuf[1+4*j:], index)
+		}
+		if _, err := bw.Write(faceBuf[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
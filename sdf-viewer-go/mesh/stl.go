@@ -0,0 +1,31 @@
+package mesh
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// WriteSTL writes the mesh in the binary STL format. STL does not support colors or shared vertices, so only the
+// triangles are written, with their normals.
+func WriteSTL(w io.Writer, m *Mesh) error {
+	bw := bufio.NewWriter(w)
+	var header [80]byte
+	copy(header[:], "sdf-viewer-go")
+	_, _ = bw.Write(header[:])
+	_ = binary.Write(bw, binary.LittleEndian, uint32(len(m.Triangles)))
+	var buf [50]byte // Normal, 3 vertices and a 16-bit attribute
+	for i, tri := range m.Triangles {
+		values := append([][3]float32{m.TriangleNormal(i)}, m.Vertices[tri[0]], m.Vertices[tri[1]], m.Vertices[tri[2]])
+		for j, v := range values {
+			for k := 0; k < 3; k++ {
+				binary.LittleEndian.PutUint32(buf[(3*j+k)*4:], math.Float32bits(v[k]))
+			}
+		}
+		if _, err := bw.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,152 @@
+package mesh
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Write3MF meshes the SDF and writes it as a 3MF package, for (multi-material) 3D printing.
+//
+// Each top-level child of the SDF is a separate object named by its Name(), with a base material of its average
+// sampled color, so that slicers pick up parts and colors automatically. The parts are clipped to the root SDF, so
+// that they add up to what the app shows: e.g. the cutter of a difference produces no object, and is skipped.
+func Write3MF(w io.Writer, s sdfviewergo.SDF, mesher Mesher) error {
+	parts := s.Children()
+	if len(parts) == 0 {
+		parts = []sdfviewergo.SDF{s}
+	}
+	var names []string
+	var colors [][3]float32
+	var meshes []*Mesh
+	for _, part := range parts {
+		m := mesher.Mesh(&clippedSDF{SDF: part, clip: s})
+		if len(m.Triangles) == 0 {
+			continue
+		}
+		var color [3]float32
+		for _, sample := range m.Samples {
+			for i, c := range sample.Color {
+				color[i] += clamp01(c) / float32(len(m.Samples))
+			}
+		}
+		names = append(names, part.Name())
+		colors = append(colors, color)
+		meshes = append(meshes, m)
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range []struct{ name, content string }{
+		{"[Content_Types].xml", threeMFContentTypes},
+		{"_rels/.rels", threeMFRels},
+	} {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(f, file.content); err != nil {
+			return err
+		}
+	}
+	f, err := archive.Create("3D/3dmodel.model")
+	if err != nil {
+		return err
+	}
+	if err = write3MFModel(f, names, colors, meshes); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// write3MFModel writes the model part of the package, with one base material and object per mesh.
+func write3MFModel(w io.Writer, names []string, colors [][3]float32, meshes []*Mesh) error {
+	bw := bufio.NewWriter(w)
+	_, _ = io.WriteString(bw, `<?xml version="1.0" encoding="UTF-8"?>
+<model unit="millimeter" xml:lang="en-US" xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02">
+ <metadata name="Application">sdf-viewer-go</metadata>
+ <resources>
+`)
+	const materialsID = 1 // Objects use the next IDs
+	if len(meshes) > 0 {
+		_, _ = fmt.Fprintf(bw, "  <basematerials id=\"%d\">\n", materialsID)
+		for i, name := range names {
+			_, _ = fmt.Fprintf(bw, "   <base name=\"%s\" displaycolor=\"#%02X%02X%02XFF\"/>\n", xmlEscape(name),
+				colorByte(colors[i][0]), colorByte(colors[i][1]), colorByte(colors[i][2]))
+		}
+		_, _ = io.WriteString(bw, "  </basematerials>\n")
+	}
+	for i, m := range meshes {
+		_, _ = fmt.Fprintf(bw, "  <object id=\"%d\" type=\"model\" name=\"%s\" pid=\"%d\" pindex=\"%d\">\n   <mesh>\n    <vertices>\n",
+			materialsID+1+i, xmlEscape(names[i]), materialsID, i)
+		for _, v := range m.Vertices {
+			_, _ = fmt.Fprintf(bw, "     <vertex x=\"%s\" y=\"%s\" z=\"%s\"/>\n", formatFloat(v[0]), formatFloat(v[1]),
+				formatFloat(v[2]))
+		}
+		_, _ = io.WriteString(bw, "    </vertices>\n    <triangles>\n")
+		for _, tri := range m.Triangles {
+			_, _ = fmt.Fprintf(bw, "     <triangle v1=\"%d\" v2=\"%d\" v3=\"%d\"/>\n", tri[0], tri[1], tri[2])
+		}
+		_, _ = io.WriteString(bw, "    </triangles>\n   </mesh>\n  </object>\n")
+	}
+	_, _ = io.WriteString(bw, " </resources>\n <build>\n")
+	for i := range meshes {
+		_, _ = fmt.Fprintf(bw, "  <item objectid=\"%d\"/>\n", materialsID+1+i)
+	}
+	_, _ = io.WriteString(bw, " </build>\n</model>\n")
+	return bw.Flush()
+}
+
+const threeMFContentTypes = `<?xml version="1.0" encoding="UTF-8"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+ <Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+ <Default Extension="model" ContentType="application/vnd.ms-package.3dmanufacturing-3dmodel+xml"/>
+</Types>
+`
+
+const threeMFRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+ <Relationship Target="/3D/3dmodel.model" Id="rel0" Type="http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"/>
+</Relationships>
+`
+
+// clippedSDF is the part of an SDF that is inside another one (their intersection), keeping the materials of the
+// former.
+type clippedSDF struct {
+	sdfviewergo.SDF
+	clip sdfviewergo.SDF
+}
+
+func (c *clippedSDF) AABB() [2][3]float32 {
+	aabb, clipAABB := c.SDF.AABB(), c.clip.AABB()
+	for i := 0; i < 3; i++ {
+		aabb[0][i] = float32(math.Max(float64(aabb[0][i]), float64(clipAABB[0][i])))
+		aabb[1][i] = float32(math.Max(float64(aabb[0][i]), math.Min(float64(aabb[1][i]), float64(clipAABB[1][i]))))
+	}
+	return aabb
+}
+
+func (c *clippedSDF) Sample(point [3]float32, distanceOnly bool) sdfviewergo.SDFSample {
+	sample := c.SDF.Sample(point, distanceOnly)
+	sample.Distance = float32(math.Max(float64(sample.Distance), float64(c.clip.Sample(point, true).Distance)))
+	return sample
+}
+
+func (c *clippedSDF) Children() []sdfviewergo.SDF {
+	return nil
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
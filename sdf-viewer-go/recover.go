@@ -0,0 +1,6 @@
+//go:build !tinygo
+
+package sdf_viewer_go
+
+// recoveredCapabilities are the capabilities that rely on recovering from panics inside exports (see recoverExport).
+const recoveredCapabilities = CapabilityLastError
@@ -0,0 +1,8 @@
+//go:build tinygo
+
+package sdf_viewer_go
+
+// recoveredCapabilities are the capabilities that rely on recovering from panics inside exports (see recoverExport).
+// TinyGo does not implement recover() on WebAssembly, where panics still trap the instance, so failures are never
+// reported through `last_error` and it is not advertised by default.
+const recoveredCapabilities Capability = 0
@@ -0,0 +1,150 @@
+package sdf_viewer_go
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// sdfRegistry assigns the IDs used by the host to the nodes of the SDF hierarchy.
+//
+// Nodes are keyed by identity (the SDF interface value, usually a pointer), so finding the ID of a child is O(1).
+// The children last reported for each node are recorded, so that nodes that are no longer reachable from the root
+// (e.g. subtrees rebuilt after a parameter change) can be forgotten and their IDs reused.
+//
+// IDs are derived from the structural path of the node (parent ID, child index and name) instead of the registration
+// order, so that they remain stable when unrelated parts of the tree change or the root is set again (e.g. after a
+// hot reload), and the app keeps its selection.
+type sdfRegistry struct {
+	nodes map[uint32]*registeredSDF
+	ids   map[SDF]uint32
+}
+
+type registeredSDF struct {
+	sdf      SDF
+	path     sdfPath
+	children []uint32 // As last reported to the host
+}
+
+// sdfPath locates a node in the hierarchy, relative to its parent.
+type sdfPath struct {
+	parentID uint32
+	index    uint32
+	name     string
+}
+
+// rootSDFID is the ID of the root SDF, which is always reachable.
+const rootSDFID uint32 = 0
+
+// rootSDFPath is the path of the root SDF, which no other node can have as the root has no parent.
+var rootSDFPath = sdfPath{parentID: rootSDFID, index: ^uint32(0)}
+
+// registry is the exported SDF hierarchy implementations.
+var registry = newSDFRegistry()
+
+func newSDFRegistry() *sdfRegistry {
+	return &sdfRegistry{
+		nodes: map[uint32]*registeredSDF{},
+		ids:   map[SDF]uint32{},
+	}
+}
+
+// get returns the SDF registered with the given ID.
+func (r *sdfRegistry) get(sdfID uint32) (SDF, bool) {
+	if node, ok := r.nodes[sdfID]; ok {
+		return node.sdf, true
+	}
+	return nil, false
+}
+
+// registerRoot registers the root SDF and all of its descendants.
+func (r *sdfRegistry) registerRoot(s SDF) {
+	r.register(s, rootSDFPath)
+}
+
+// register returns the ID of the given SDF, registering it (and all of its descendants) at the given path if it is not
+// known yet. Any other node previously registered at the same path is replaced.
+func (r *sdfRegistry) register(s SDF, path sdfPath) uint32 {
+	if sdfID, ok := r.ids[s]; ok {
+		return sdfID // Also stops recursion on shared subtrees (and cycles)
+	}
+	sdfID := r.idForPath(path)
+	if previous, ok := r.nodes[sdfID]; ok {
+		delete(r.ids, previous.sdf) // A new instance of the same node (e.g. a rebuilt subtree) takes over its ID
+	}
+	node := &registeredSDF{sdf: s, path: path}
+	r.nodes[sdfID] = node
+	r.ids[s] = sdfID
+	//fmt.Printf("register(%d)\n", sdfID)
+	for i, child := range s.Children() {
+		node.children = append(node.children, r.register(child, sdfPath{parentID: sdfID, index: uint32(i), name: child.Name()}))
+	}
+	return sdfID
+}
+
+// idForPath derives the ID for the given path by hashing it. On collisions with nodes at other paths, the next IDs are
+// probed in order, so the result only depends on the paths registered before (in depth-first order).
+func (r *sdfRegistry) idForPath(path sdfPath) uint32 {
+	if path == rootSDFPath {
+		return rootSDFID
+	}
+	hash := fnv.New32a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint32(buf[:4], path.parentID)
+	binary.LittleEndian.PutUint32(buf[4:], path.index)
+	_, _ = hash.Write(buf[:])
+	_, _ = hash.Write([]byte(path.name))
+	sdfID := hash.Sum32()
+	for {
+		if sdfID != rootSDFID {
+			node, ok := r.nodes[sdfID]
+			if !ok || node.path == path {
+				return sdfID
+			}
+		}
+		sdfID++
+	}
+}
+
+// childrenIDs registers the current children of the given SDF and returns their IDs.
+// If the children changed since the last call, unreachable nodes are collected.
+func (r *sdfRegistry) childrenIDs(sdfID uint32, children []SDF) []uint32 {
+	node := r.nodes[sdfID]
+	ids := make([]uint32, len(children))
+	changed := len(ids) != len(node.children)
+	for i, child := range children {
+		_, known := r.ids[child]
+		ids[i] = r.register(child, sdfPath{parentID: sdfID, index: uint32(i), name: child.Name()})
+		changed = changed || !known || ids[i] != node.children[i]
+	}
+	node.children = ids
+	if changed {
+		r.collect()
+	}
+	return ids
+}
+
+// collect forgets all nodes that are not reachable from the root, so that their IDs can be reused.
+func (r *sdfRegistry) collect() {
+	reachable := make(map[uint32]bool, len(r.nodes))
+	pending := []uint32{rootSDFID}
+	for len(pending) > 0 {
+		sdfID := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if reachable[sdfID] {
+			continue
+		}
+		reachable[sdfID] = true
+		if node, ok := r.nodes[sdfID]; ok {
+			pending = append(pending, node.children...)
+		}
+	}
+	for sdfID, node := range r.nodes {
+		if !reachable[sdfID] {
+			//fmt.Printf("collect(%d)\n", sdfID)
+			delete(r.nodes, sdfID)
+			if r.ids[node.sdf] == sdfID {
+				delete(r.ids, node.sdf)
+			}
+		}
+	}
+}
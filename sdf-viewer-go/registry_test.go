@@ -0,0 +1,68 @@
+package sdf_viewer_go
+
+import (
+	"strconv"
+	"testing"
+)
+
+// registryTestSDF is a minimal node, whose children may be rebuilt on every call.
+type registryTestSDF struct {
+	name     string
+	children func() []SDF
+}
+
+func (s *registryTestSDF) AABB() (aabb [2][3]float32) { return }
+
+func (s *registryTestSDF) Sample(_ [3]float32, _ bool) (sample SDFSample) { return }
+
+func (s *registryTestSDF) Children() []SDF {
+	if s.children == nil {
+		return nil
+	}
+	return s.children()
+}
+
+func (s *registryTestSDF) Name() string { return s.name }
+
+func (s *registryTestSDF) Parameters() []SDFParam { return nil }
+
+func (s *registryTestSDF) SetParameter(_ uint32, _ SDFParamValue) error { return nil }
+
+func (s *registryTestSDF) Changed() ChangedAABB { return ChangedAABB{} }
+
+func TestRegistryCollectsRebuiltSubtrees(t *testing.T) {
+	// Each call to Children rebuilds the whole subtree with a different size (up to 5 children with 3 leaves each), and
+	// with leaves at new paths (so they can't just take over the IDs of the previous ones)
+	rebuilds := 0
+	root := &registryTestSDF{name: "root", children: func() []SDF {
+		rebuilds++
+		children := make([]SDF, rebuilds%5+1)
+		for i := range children {
+			leaves := make([]SDF, (rebuilds+i)%4)
+			for j := range leaves {
+				leaves[j] = &registryTestSDF{name: "leaf" + strconv.Itoa(rebuilds)}
+			}
+			children[i] = &registryTestSDF{name: "child" + strconv.Itoa(i), children: func() []SDF { return leaves }}
+		}
+		return children
+	}}
+	const maxNodes = 1 + 5 + 5*3
+
+	r := newSDFRegistry()
+	r.registerRoot(root)
+	for i := 0; i < 200; i++ {
+		// Walk the tree as the host does, so every level reports its (new) children
+		pending := []uint32{rootSDFID}
+		for len(pending) > 0 {
+			sdfID := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			pending = append(pending, r.childrenIDs(sdfID, r.nodes[sdfID].sdf.Children())...)
+		}
+		if len(r.nodes) > maxNodes {
+			t.Fatalf("iteration %d: %d nodes registered, expected at most %d", i, len(r.nodes), maxNodes)
+		}
+		if len(r.ids) != len(r.nodes) {
+			t.Fatalf("iteration %d: %d identities for %d nodes", i, len(r.ids), len(r.nodes))
+		}
+	}
+}
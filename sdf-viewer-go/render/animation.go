@@ -0,0 +1,172 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WriteGIF writes the frames as a looping animated GIF, with the given delay between frames. Colors are dithered to
+// a fixed palette, and transparency is kept (fully transparent or opaque pixels only).
+func WriteGIF(w io.Writer, frames []*image.NRGBA, delay time.Duration) error {
+	if len(frames) == 0 {
+		return errors.New("no frames to write")
+	}
+	delayCs := int(delay / (10 * time.Millisecond))
+	if delayCs < 2 {
+		delayCs = 2 // Smaller delays are slowed down by most viewers
+	}
+	anim := &gif.GIF{BackgroundIndex: 0}
+	for _, frame := range frames {
+		paletted := image.NewPaletted(frame.Bounds(), gifPalette)
+		draw.FloydSteinberg.Draw(paletted, frame.Bounds(), frame, frame.Bounds().Min)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delayCs)
+		anim.Disposal = append(anim.Disposal, gif.DisposalBackground) // Clear to transparent
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// gifPalette is the transparent color, the 216 web-safe colors and extra grays.
+var gifPalette = func() color.Palette {
+	p := color.Palette{color.NRGBA{}}
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				p = append(p, color.NRGBA{R: uint8(r * 51), G: uint8(g * 51), B: uint8(b * 51), A: 255})
+			}
+		}
+	}
+	for i := 0; len(p) < 256; i++ {
+		gray := uint8(6 + i*255/40)
+		p = append(p, color.NRGBA{R: gray, G: gray, B: gray, A: 255})
+	}
+	return p
+}()
+
+// WriteAPNG writes the frames (of the same size) as a looping animated PNG, with the given delay between frames.
+// Unlike GIF, it keeps all the colors and the alpha channel.
+func WriteAPNG(w io.Writer, frames []*image.NRGBA, delay time.Duration) error {
+	if len(frames) == 0 {
+		return errors.New("no frames to write")
+	}
+	size := frames[0].Bounds().Size()
+	delayMs := delay.Milliseconds()
+	if delayMs > 0xffff {
+		delayMs = 0xffff
+	}
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("\x89PNG\r\n\x1a\n")
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(size.X))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(size.Y))
+	ihdr[8], ihdr[9] = 8, 6 // 8-bit RGBA
+	writePNGChunk(bw, "IHDR", ihdr)
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(frames)))
+	// The number of plays is 0: loop forever
+	writePNGChunk(bw, "acTL", actl)
+	sequence := uint32(0)
+	for i, frame := range frames {
+		if frame.Bounds().Size() != size {
+			return fmt.Errorf("frame %d is %v instead of %v", i, frame.Bounds().Size(), size)
+		}
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], sequence)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(size.X))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(size.Y))
+		binary.BigEndian.PutUint16(fctl[20:], uint16(delayMs))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		// Offsets, dispose_op (none) and blend_op (source, replacing the previous frame) are 0
+		writePNGChunk(bw, "fcTL", fctl)
+		sequence++
+		data, err := pngImageData(frame)
+		if err != nil {
+			return err
+		}
+		if i == 0 { // The first frame is also the static image, for viewers without APNG support
+			writePNGChunk(bw, "IDAT", data)
+		} else {
+			fdat := binary.BigEndian.AppendUint32(nil, sequence)
+			writePNGChunk(bw, "fdAT", append(fdat, data...))
+			sequence++
+		}
+	}
+	writePNGChunk(bw, "IEND", nil)
+	return bw.Flush()
+}
+
+// pngImageData returns the compressed 8-bit RGBA scanlines of the image, with the Sub filter.
+func pngImageData(img *image.NRGBA) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	bounds := img.Bounds()
+	row := make([]byte, 1+4*bounds.Dx())
+	row[0] = 1 // Sub filter: the difference with the pixel to the left
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		pix := img.Pix[img.PixOffset(bounds.Min.X, y):][:4*bounds.Dx()]
+		for i := range pix {
+			if i < 4 {
+				row[1+i] = pix[i]
+			} else {
+				row[1+i] = pix[i] - pix[i-4]
+			}
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writePNGChunk(w *bufio.Writer, kind string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], kind)
+	_, _ = w.Write(header[:])
+	_, _ = w.Write(data)
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(header[4:])
+	_, _ = crc.Write(data)
+	_, _ = w.Write(binary.BigEndian.AppendUint32(nil, crc.Sum32()))
+}
+
+// SaveAnimation writes the frames to the given path, as a GIF or an APNG depending on its extension (.gif, or .png
+// or .apng).
+func SaveAnimation(path string, frames []*image.NRGBA, delay time.Duration) (err error) {
+	var write func(io.Writer, []*image.NRGBA, time.Duration) error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".gif":
+		write = WriteGIF
+	case ".png", ".apng":
+		write = WriteAPNG
+	default:
+		return fmt.Errorf("unsupported animation format %q (expected .gif, .png or .apng)", ext)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return write(f, frames, delay)
+}
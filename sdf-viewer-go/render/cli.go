@@ -0,0 +1,116 @@
+//go:build !wasm
+
+package render
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	sdfviewergo.RegisterCLICommand("render", sdfviewergo.CLICommand{
+		Usage: "render [-w W] [-h H] [-az DEG] [-el DEG] FILE.png\n" +
+			"                            Render the scene on the CPU (without the app) to a PNG image",
+		Run: runCLI,
+	})
+	sdfviewergo.RegisterCLICommand("turntable", sdfviewergo.CLICommand{
+		Usage: "turntable [-w W] [-h H] [-frames N] [-fps N] [-el DEG] [-sweep ID:PARAM=FROM:TO] FILE.gif|FILE.png\n" +
+			"                            Render an animation orbiting the scene to a GIF or APNG, optionally sweeping a\n" +
+			"                            parameter",
+		Run: runTurntableCLI,
+	})
+}
+
+func runCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	width := flags.Int("w", 640, "width of the image")
+	height := flags.Int("h", 480, "height of the image")
+	azimuth := flags.Float64("az", -30, "azimuth of the camera around the Z axis, in degrees")
+	elevation := flags.Float64("el", 25, "elevation of the camera above the XY plane, in degrees")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("render: expected the output file")
+	}
+	start := time.Now()
+	camera := OrbitCamera(root.AABB(), float32(*azimuth), float32(*elevation))
+	img := Renderer{Width: *width, Height: *height, Camera: &camera}.Render(root)
+	if err := SavePNG(flags.Arg(0), img); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "%s (%s)\n", flags.Arg(0), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func runTurntableCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("turntable", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	width := flags.Int("w", 320, "width of the frames")
+	height := flags.Int("h", 240, "height of the frames")
+	frames := flags.Int("frames", 36, "number of frames of a full turn")
+	fps := flags.Float64("fps", 12, "frames per second")
+	elevation := flags.Float64("el", 25, "elevation of the camera above the XY plane, in degrees")
+	sweep := flags.String("sweep", "", "sweep a float or int parameter forth and back (ID:PARAM=FROM:TO)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("turntable: expected the output file")
+	}
+	if *fps <= 0 {
+		return errors.New("turntable: the frames per second must be positive")
+	}
+	start := time.Now()
+	t := Turntable{Renderer: Renderer{Width: *width, Height: *height}, Frames: *frames, Azimuth: -30,
+		Elevation: float32(*elevation)}
+	if *sweep != "" {
+		var err error
+		if t.Sweep, err = parseSweep(*sweep); err != nil {
+			return err
+		}
+	}
+	images, err := t.Render(root)
+	if err != nil {
+		return err
+	}
+	if err = SaveAnimation(flags.Arg(0), images, time.Duration(float64(time.Second) / *fps)); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "%s: %d frames (%s)\n", flags.Arg(0), len(images), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// parseSweep parses ID:PARAM=FROM:TO.
+func parseSweep(sweep string) (*Sweep, error) {
+	idStr, rest, ok1 := strings.Cut(sweep, ":")
+	paramStr, rangeStr, ok2 := strings.Cut(rest, "=")
+	fromStr, toStr, ok3 := strings.Cut(rangeStr, ":")
+	if !ok1 || !ok2 || !ok3 {
+		return nil, fmt.Errorf("invalid -sweep %q: expected ID:PARAM=FROM:TO", sweep)
+	}
+	s, err := sdfviewergo.CLIGetSDF(idStr)
+	if err != nil {
+		return nil, err
+	}
+	paramID, err := strconv.ParseUint(paramStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter ID %q: %w", paramStr, err)
+	}
+	from, err := strconv.ParseFloat(fromStr, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -sweep start %q: %w", fromStr, err)
+	}
+	to, err := strconv.ParseFloat(toStr, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -sweep end %q: %w", toStr, err)
+	}
+	return &Sweep{SDF: s, ParamID: uint32(paramID), From: float32(from), To: float32(to)}, nil
+}
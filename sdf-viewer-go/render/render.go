@@ -0,0 +1,187 @@
+// Package render draws any sdfviewergo.SDF to an image on the CPU with sphere tracing, to preview scenes where the
+// SDF Viewer app (which needs a GPU) can't run, e.g. on headless CI machines.
+package render
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Renderer sphere-traces SDFs to images, shading them with the color, metallic, roughness and occlusion of their
+// samples under simple directional lights.
+//
+// Rows are rendered in parallel, so Sample must be safe for concurrent use, which is the case for the supported
+// libraries once their caches are filled: the renderer fills them by sampling each node of the hierarchy before
+// starting. Set Workers to 1 for other SDFs.
+type Renderer struct {
+	// Width and Height are the size of the image in pixels (640x480 if 0).
+	Width, Height int
+	// Camera is the point of view (DefaultCamera of the bounding box if nil).
+	Camera *Camera
+	// Lights are the directional lights of the scene (DefaultLights if nil).
+	Lights []Light
+	// Background is the color of the pixels that do not hit the surface (transparent if zero).
+	Background color.NRGBA
+	// MaxSteps is the maximum number of sphere tracing steps for each ray (256 if 0).
+	MaxSteps int
+	// Workers is the number of goroutines rendering rows (runtime.NumCPU() if 0).
+	Workers int
+}
+
+// Camera is a perspective camera.
+type Camera struct {
+	// Position is the position of the eye.
+	Position [3]float32
+	// Target is the point at the center of the image.
+	Target [3]float32
+	// Up is the direction that points to the top of the image.
+	Up [3]float32
+	// FOV is the vertical field of view, in degrees.
+	FOV float32
+}
+
+// DefaultCamera looks at the bounding box from the front-right and above, with the Z axis up.
+func DefaultCamera(aabb [2][3]float32) Camera {
+	return OrbitCamera(aabb, -30, 25)
+}
+
+// OrbitCamera looks at the center of the bounding box from the given azimuth (around the Z axis, 0 looks along +Y)
+// and elevation (above the XY plane), in degrees, from a distance that fits the whole bounding box.
+func OrbitCamera(aabb [2][3]float32, azimuth, elevation float32) Camera {
+	const fov = 40
+	elevation = float32(math.Max(-89, math.Min(89, float64(elevation)))) // Up would be parallel to the view
+	var center [3]float32
+	radius := float32(0)
+	for i := 0; i < 3; i++ {
+		center[i] = (aabb[0][i] + aabb[1][i]) / 2
+		radius += (aabb[1][i] - center[i]) * (aabb[1][i] - center[i])
+	}
+	distance := float32(math.Sqrt(float64(radius)) / math.Sin(fov/2*math.Pi/180))
+	az, el := float64(azimuth)*math.Pi/180, float64(elevation)*math.Pi/180
+	direction := [3]float32{float32(math.Sin(az) * math.Cos(el)), float32(-math.Cos(az) * math.Cos(el)),
+		float32(math.Sin(el))}
+	return Camera{
+		Position: add(center, scale(direction, distance)),
+		Target:   center,
+		Up:       [3]float32{0, 0, 1},
+		FOV:      fov,
+	}
+}
+
+// Light is a directional light.
+type Light struct {
+	// Direction points towards the light.
+	Direction [3]float32
+	// Color is the (linear) color and intensity of the light.
+	Color [3]float32
+}
+
+// DefaultLights are a key light from the top-front-right and a dimmer fill light from the left.
+var DefaultLights = []Light{
+	{Direction: [3]float32{0.5, -1, 1.5}, Color: [3]float32{1, 0.98, 0.95}},
+	{Direction: [3]float32{-1, -0.3, 0.2}, Color: [3]float32{0.3, 0.32, 0.35}},
+}
+
+// Render renders the SDF to a new image.
+func (r Renderer) Render(s sdfviewergo.SDF) *image.NRGBA {
+	width, height := r.Width, r.Height
+	if width <= 0 || height <= 0 {
+		width, height = 640, 480
+	}
+	camera := r.Camera
+	if camera == nil {
+		defaultCamera := DefaultCamera(s.AABB())
+		camera = &defaultCamera
+	}
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > 1 {
+		warmUp(s, map[sdfviewergo.SDF]bool{})
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	t := newTracer(r, s, *camera, width, height)
+	var nextRow int32 = -1
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := int(atomic.AddInt32(&nextRow, 1)); y < height; y = int(atomic.AddInt32(&nextRow, 1)) {
+				t.renderRow(img, y)
+			}
+		}()
+	}
+	wg.Wait()
+	return img
+}
+
+// warmUp samples each node of the hierarchy once, so that lazily filled caches are not written concurrently later.
+func warmUp(s sdfviewergo.SDF, ancestors map[sdfviewergo.SDF]bool) {
+	aabb := s.AABB()
+	s.Sample(aabb[0], false)
+	ancestors[s] = true
+	for _, child := range s.Children() {
+		if !ancestors[child] {
+			warmUp(child, ancestors)
+		}
+	}
+	delete(ancestors, s)
+}
+
+// SavePNG writes the image to the given path as a PNG file.
+func SavePNG(path string, img image.Image) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return png.Encode(f, img)
+}
+
+// === Vector helpers ===
+
+func add(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func sub(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func scale(a [3]float32, k float32) [3]float32 {
+	return [3]float32{a[0] * k, a[1] * k, a[2] * k}
+}
+
+func dot(a, b [3]float32) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b [3]float32) [3]float32 {
+	return [3]float32{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func normalize(a [3]float32) [3]float32 {
+	length := float32(math.Sqrt(float64(dot(a, a))))
+	if length == 0 {
+		return a
+	}
+	return scale(a, 1/length)
+}
+
+func clamp01(v float32) float32 {
+	return float32(math.Min(math.Max(float64(v), 0), 1))
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"bytes"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/testsdf"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+// red is the material of the test spheres.
+var red = sdfviewergo.SDFSample{Color: [3]float32{1, 0, 0}, Roughness: 1}
+
+func TestRender(t *testing.T) {
+	img := Renderer{Width: 64, Height: 48}.Render(&testsdf.Sphere{Radius: 1, Material: red})
+	if c := img.NRGBAAt(0, 0); c != (color.NRGBA{}) {
+		t.Fatalf("expected a transparent background, got %v", c)
+	}
+	if c := img.NRGBAAt(32, 24); c.A != 255 || c.R < 128 || c.G > c.R/4 || c.B > c.R/4 {
+		t.Fatalf("expected a red sphere at the center, got %v", c)
+	}
+	// The key light comes from the top right, so that side is brighter
+	if bright, dark := img.NRGBAAt(36, 20), img.NRGBAAt(28, 28); bright.R <= dark.R {
+		t.Fatalf("unexpected shading: %v (top right) vs %v (bottom left)", bright, dark)
+	}
+
+	sequential := Renderer{Width: 64, Height: 48, Workers: 1}.Render(&testsdf.Sphere{Radius: 1, Material: red})
+	for i := range img.Pix {
+		if img.Pix[i] != sequential.Pix[i] {
+			t.Fatal("the image depends on the number of workers")
+		}
+	}
+}
+
+// sizedSphere is a sphere with its radius as a parameter.
+type sizedSphere struct {
+	testsdf.Sphere
+}
+
+func (s *sizedSphere) Parameters() []sdfviewergo.SDFParam {
+	return []sdfviewergo.SDFParam{{ID: 0, Name: "radius", Kind: sdfviewergo.SDFParamKindFloat{Min: 0.1, Max: 2, Step: 0.1},
+		Value: s.Radius}}
+}
+
+func (s *sizedSphere) SetParameter(_ uint32, value sdfviewergo.SDFParamValue) error {
+	s.Radius = value.(float32)
+	return nil
+}
+
+func TestTurntable(t *testing.T) {
+	s := &sizedSphere{testsdf.Sphere{Radius: 1, Material: red}}
+	frames, err := Turntable{Renderer: Renderer{Width: 32, Height: 24}, Frames: 4,
+		Sweep: &Sweep{SDF: s, ParamID: 0, From: 0.5, To: 2}}.Render(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 4 {
+		t.Fatalf("expected 4 frames, got %d", len(frames))
+	}
+	if s.Radius != 1 {
+		t.Fatalf("the parameter was not restored: %v", s.Radius)
+	}
+	coverage := func(img *image.NRGBA) (res int) {
+		for i := 3; i < len(img.Pix); i += 4 {
+			if img.Pix[i] != 0 {
+				res++
+			}
+		}
+		return res
+	}
+	// Frame 2 is at the middle of the sweep, and the camera fits the biggest sphere in all frames
+	if small, big, back := coverage(frames[0]), coverage(frames[2]), coverage(frames[3]); !(small < back && back < big) {
+		t.Fatalf("unexpected sweep: %d, %d and %d pixels of the sphere", small, big, back)
+	}
+
+	var buf bytes.Buffer
+	if err = WriteGIF(&buf, frames, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 4 || anim.Delay[0] != 10 {
+		t.Fatalf("unexpected GIF: %d frames with a delay of %d", len(anim.Image), anim.Delay[0])
+	}
+
+	buf.Reset()
+	if err = WriteAPNG(&buf, frames, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("acTL")) || bytes.Count(buf.Bytes(), []byte("fdAT")) != 3 {
+		t.Fatal("expected an animation control chunk and 3 frame data chunks")
+	}
+	first, err := png.Decode(&buf) // Viewers without APNG support show the first frame
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range frames[0].Pix {
+		if first.(*image.NRGBA).Pix[i] != frames[0].Pix[i] {
+			t.Fatal("the static image is not the first frame")
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package rendertest provides golden-image snapshot tests for scenes: a few fixed views of an SDF are rendered on the
+// CPU and compared to checked-in PNG images, so that regressions in the geometry or materials show up in `go test`.
+//
+// Run `go test -update` to (re)generate the golden images after an intended change, and review them before committing.
+// The test package must define the -update flag, like for its other golden files:
+//
+//	var update = flag.Bool("update", false, "update the golden files")
+package rendertest
+
+import (
+	"flag"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/render"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// updating returns true if the golden images must be regenerated instead of compared.
+// The flag is looked up when needed instead of being defined here, as test packages define their own.
+func updating() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// View is a fixed point of view for the golden images.
+type View struct {
+	// Name is the suffix of the golden image.
+	Name string
+	// Azimuth and Elevation are the ones of render.OrbitCamera, in degrees.
+	Azimuth, Elevation float32
+}
+
+// Options configure the golden image comparison. The zero value uses the defaults.
+type Options struct {
+	// Dir is the directory of the golden images ("testdata/golden" if empty).
+	Dir string
+	// Width and Height are the size of the images (160x120 if 0).
+	Width, Height int
+	// Views are the points of view to render (DefaultViews if nil).
+	Views []View
+	// Threshold is the minimum perceptual difference (0 to 1) between the colors of two pixels to count as different
+	// (0.1 if 0).
+	Threshold float64
+	// MaxDiffRatio is the maximum ratio of different pixels for the images to match (0.005 if 0).
+	MaxDiffRatio float64
+	// Material replaces the materials of the SDF if set, to only check the geometry (e.g. when the materials are not
+	// deterministic).
+	Material *sdfviewergo.SDFSample
+}
+
+// DefaultViews are the front, right, top and isometric views.
+var DefaultViews = []View{
+	{Name: "front", Azimuth: 0, Elevation: 0},
+	{Name: "right", Azimuth: 90, Elevation: 0},
+	{Name: "top", Azimuth: 0, Elevation: 89},
+	{Name: "iso", Azimuth: -30, Elevation: 25},
+}
+
+// Golden checks the views of the SDF against the golden images named after the given name, with the default options.
+func Golden(t testing.TB, name string, s sdfviewergo.SDF) {
+	t.Helper()
+	Options{}.Golden(t, name, s)
+}
+
+// Golden checks the views of the SDF against the golden images named after the given name.
+// On mismatch, the actual and diff images are written next to the golden ones (as .actual.png and .diff.png).
+func (o Options) Golden(t testing.TB, name string, s sdfviewergo.SDF) {
+	t.Helper()
+	o.setDefaults()
+	if o.Material != nil {
+		s = &materialSDF{SDF: s, material: *o.Material}
+	}
+	for _, view := range o.Views {
+		camera := render.OrbitCamera(s.AABB(), view.Azimuth, view.Elevation)
+		actual := render.Renderer{Width: o.Width, Height: o.Height, Camera: &camera}.Render(s)
+		path := filepath.Join(o.Dir, name+"_"+view.Name+".png")
+		if updating() {
+			if err := os.MkdirAll(o.Dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := render.SavePNG(path, actual); err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("updated %s", path)
+			continue
+		}
+		golden, err := loadPNG(path)
+		if err != nil {
+			t.Errorf("%v (run `go test -update` to create it)", err)
+			continue
+		}
+		diff, ratio := compare(golden, actual, o.Threshold)
+		if diff == nil {
+			t.Errorf("%s: the size of the golden image is %v instead of %v", path, golden.Bounds().Size(),
+				actual.Bounds().Size())
+			continue
+		}
+		if ratio > o.MaxDiffRatio {
+			base := path[:len(path)-len(".png")]
+			_ = render.SavePNG(base+".actual.png", actual)
+			_ = render.SavePNG(base+".diff.png", diff)
+			t.Errorf("%s: %.2f%% of the pixels differ (max %.2f%%), see %s.diff.png", path, ratio*100,
+				o.MaxDiffRatio*100, base)
+		}
+	}
+}
+
+func (o *Options) setDefaults() {
+	if o.Dir == "" {
+		o.Dir = filepath.Join("testdata", "golden")
+	}
+	if o.Width <= 0 || o.Height <= 0 {
+		o.Width, o.Height = 160, 120
+	}
+	if o.Views == nil {
+		o.Views = DefaultViews
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = 0.1
+	}
+	if o.MaxDiffRatio <= 0 {
+		o.MaxDiffRatio = 0.005
+	}
+}
+
+// materialSDF replaces the material of all the samples of an SDF.
+type materialSDF struct {
+	sdfviewergo.SDF
+	material sdfviewergo.SDFSample
+}
+
+func (m *materialSDF) Sample(point [3]float32, _ bool) sdfviewergo.SDFSample {
+	sample := m.material
+	sample.Distance = m.SDF.Sample(point, true).Distance
+	return sample
+}
+
+func (m *materialSDF) SampleBatch(points [][3]float32, _ bool, samples []sdfviewergo.SDFSample) {
+	sdfviewergo.SampleBatch(m.SDF, points, true, samples)
+	for i := range samples {
+		distance := samples[i].Distance
+		samples[i] = m.material
+		samples[i].Distance = distance
+	}
+}
+
+func loadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return png.Decode(f)
+}
+
+// compare returns a diff image (a faded copy of the golden image with the different pixels in red) and the ratio of
+// different pixels, or nil if the sizes do not match.
+func compare(golden, actual image.Image, threshold float64) (*image.NRGBA, float64) {
+	bounds := golden.Bounds()
+	if bounds.Size() != actual.Bounds().Size() {
+		return nil, 1
+	}
+	diff := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	different := 0
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			a := color.NRGBAModel.Convert(golden.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			b := color.NRGBAModel.Convert(actual.At(actual.Bounds().Min.X+x, actual.Bounds().Min.Y+y)).(color.NRGBA)
+			if perceptualDelta(a, b) > threshold {
+				different++
+				diff.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				gray := uint8(255 - (255-int(luma(a)))/4) // Faded, to highlight the differences
+				diff.SetNRGBA(x, y, color.NRGBA{R: gray, G: gray, B: gray, A: 255})
+			}
+		}
+	}
+	return diff, float64(different) / float64(bounds.Dx()*bounds.Dy())
+}
+
+// perceptualDelta returns the perceptual difference between two colors from 0 to 1, using the weighted YIQ distance
+// of "Measuring perceived color difference using YIQ NTSC transmission color space in mobile applications"
+// (Kotsarenko and Ramos), after blending them over white.
+func perceptualDelta(a, b color.NRGBA) float64 {
+	ya, ia, qa := yiq(a)
+	yb, ib, qb := yiq(b)
+	dy, di, dq := ya-yb, ia-ib, qa-qb
+	return math.Sqrt((0.5053*dy*dy + 0.299*di*di + 0.1957*dq*dq) / 35215)
+}
+
+func yiq(c color.NRGBA) (y, i, q float64) {
+	alpha := float64(c.A) / 255
+	r := 255 + (float64(c.R)-255)*alpha
+	g := 255 + (float64(c.G)-255)*alpha
+	b := 255 + (float64(c.B)-255)*alpha
+	return r*0.29889531 + g*0.58662247 + b*0.11448223,
+		r*0.59597799 - g*0.27417610 - b*0.32180189,
+		r*0.21147017 - g*0.52261711 + b*0.31114694
+}
+
+func luma(c color.NRGBA) uint8 {
+	y, _, _ := yiq(c)
+	return uint8(math.Round(y))
+}
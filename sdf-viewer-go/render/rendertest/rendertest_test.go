@@ -0,0 +1,27 @@
+package rendertest
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	golden := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	actual := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for i := range golden.Pix {
+		golden.Pix[i], actual.Pix[i] = 200, 200
+	}
+	actual.SetNRGBA(1, 1, color.NRGBA{R: 202, G: 200, B: 199, A: 255}) // Imperceptible
+	actual.SetNRGBA(2, 2, color.NRGBA{R: 255, A: 255})
+	diff, ratio := compare(golden, actual, 0.1)
+	if ratio != 0.01 {
+		t.Fatalf("expected 1 different pixel, got a ratio of %v", ratio)
+	}
+	if diff.NRGBAAt(2, 2) != (color.NRGBA{R: 255, A: 255}) || diff.NRGBAAt(1, 1).G == 0 {
+		t.Fatal("expected only the different pixel to be highlighted")
+	}
+	if diff, _ = compare(golden, image.NewNRGBA(image.Rect(0, 0, 5, 5)), 0.1); diff != nil {
+		t.Fatal("expected a size mismatch")
+	}
+}
@@ -0,0 +1,187 @@
+package render
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"image"
+	"image/color"
+	"math"
+)
+
+// tracer holds the per-image state shared by all rows.
+type tracer struct {
+	s          sdfviewergo.SDF
+	aabb       [2][3]float32
+	lights     []Light // With normalized directions
+	background color.NRGBA
+	maxSteps   int
+	width      int
+	height     int
+	eye        [3]float32
+	forward    [3]float32
+	right      [3]float32 // Scaled to the half width of the image plane at distance 1
+	up         [3]float32 // Scaled to the half height of the image plane at distance 1
+	pixelAngle float32    // Of a pixel, to stop rays once they hit the surface at the pixel's footprint
+	minEpsilon float32
+}
+
+func newTracer(r Renderer, s sdfviewergo.SDF, camera Camera, width, height int) *tracer {
+	t := &tracer{s: s, aabb: s.AABB(), background: r.Background, maxSteps: r.MaxSteps, width: width, height: height,
+		eye: camera.Position}
+	if t.maxSteps <= 0 {
+		t.maxSteps = 256
+	}
+	lights := r.Lights
+	if lights == nil {
+		lights = DefaultLights
+	}
+	for _, light := range lights {
+		t.lights = append(t.lights, Light{Direction: normalize(light.Direction), Color: light.Color})
+	}
+	fov := camera.FOV
+	if fov <= 0 {
+		fov = 40
+	}
+	tanHalfFOV := float32(math.Tan(float64(fov) / 2 * math.Pi / 180))
+	t.forward = normalize(sub(camera.Target, camera.Position))
+	t.right = scale(normalize(cross(t.forward, camera.Up)), tanHalfFOV*float32(width)/float32(height))
+	t.up = scale(normalize(cross(normalize(t.right), t.forward)), tanHalfFOV)
+	t.pixelAngle = 2 * tanHalfFOV / float32(height)
+	t.minEpsilon = 1e-5 * float32(math.Sqrt(float64(dot(sub(t.aabb[1], t.aabb[0]), sub(t.aabb[1], t.aabb[0])))))
+	return t
+}
+
+// ray is a ray that is being traced.
+type ray struct {
+	x         int
+	direction [3]float32
+	distance  float32 // Traveled from the eye
+	far       float32 // Where it leaves the bounding box
+}
+
+// renderRow traces all the pixels of a row in lockstep, sampling the SDF in batches.
+func (t *tracer) renderRow(img *image.NRGBA, y int) {
+	active := make([]ray, 0, t.width)
+	for x := 0; x < t.width; x++ {
+		img.SetNRGBA(x, y, t.background)
+		u := (2*(float32(x)+0.5)/float32(t.width) - 1)
+		v := 1 - 2*(float32(y)+0.5)/float32(t.height)
+		direction := normalize(add(t.forward, add(scale(t.right, u), scale(t.up, v))))
+		if near, far, ok := t.intersectAABB(direction); ok {
+			active = append(active, ray{x: x, direction: direction, distance: near, far: far})
+		}
+	}
+
+	var hits []ray
+	points := make([][3]float32, len(active))
+	samples := make([]sdfviewergo.SDFSample, len(active))
+	for step := 0; step < t.maxSteps && len(active) > 0; step++ {
+		points, samples = points[:len(active)], samples[:len(active)]
+		for i, r := range active {
+			points[i] = add(t.eye, scale(r.direction, r.distance))
+		}
+		sdfviewergo.SampleBatch(t.s, points, true, samples)
+		remaining := active[:0]
+		for i, r := range active {
+			if samples[i].Distance < t.epsilon(r.distance) {
+				hits = append(hits, r)
+				continue
+			}
+			r.distance += samples[i].Distance
+			if r.distance <= r.far {
+				remaining = append(remaining, r)
+			}
+		}
+		active = remaining
+	}
+	if len(hits) == 0 {
+		return
+	}
+
+	// Shade the hits, with the normals from the gradient (central differences)
+	hitPoints := make([][3]float32, len(hits))
+	points = make([][3]float32, 6*len(hits))
+	for i, r := range hits {
+		hitPoints[i] = add(t.eye, scale(r.direction, r.distance))
+		h := t.epsilon(r.distance)
+		for axis := 0; axis < 3; axis++ {
+			points[6*i+2*axis], points[6*i+2*axis+1] = hitPoints[i], hitPoints[i]
+			points[6*i+2*axis][axis] += h
+			points[6*i+2*axis+1][axis] -= h
+		}
+	}
+	materials := make([]sdfviewergo.SDFSample, len(hits))
+	sdfviewergo.SampleBatch(t.s, hitPoints, false, materials)
+	samples = make([]sdfviewergo.SDFSample, len(points))
+	sdfviewergo.SampleBatch(t.s, points, true, samples)
+	for i, r := range hits {
+		var normal [3]float32
+		for axis := 0; axis < 3; axis++ {
+			normal[axis] = samples[6*i+2*axis].Distance - samples[6*i+2*axis+1].Distance
+		}
+		img.SetNRGBA(r.x, y, t.shade(materials[i], normalize(normal), scale(r.direction, -1)))
+	}
+}
+
+// epsilon is the distance to the surface that counts as a hit for a ray that traveled the given distance.
+func (t *tracer) epsilon(distance float32) float32 {
+	return float32(math.Max(float64(t.minEpsilon), float64(distance*t.pixelAngle/2)))
+}
+
+// intersectAABB returns the range of distances along the ray from the eye that are inside the bounding box.
+func (t *tracer) intersectAABB(direction [3]float32) (near, far float32, ok bool) {
+	near, far = 0, float32(math.Inf(1))
+	for i := 0; i < 3; i++ {
+		if direction[i] == 0 {
+			if t.eye[i] < t.aabb[0][i] || t.eye[i] > t.aabb[1][i] {
+				return 0, 0, false
+			}
+			continue
+		}
+		t0 := (t.aabb[0][i] - t.eye[i]) / direction[i]
+		t1 := (t.aabb[1][i] - t.eye[i]) / direction[i]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		near = float32(math.Max(float64(near), float64(t0)))
+		far = float32(math.Min(float64(far), float64(t1)))
+	}
+	return near, far, near <= far
+}
+
+// shade returns the color of a surface point, with a simple physically inspired model: Lambertian diffuse for
+// dielectrics, normalized Blinn-Phong specular (with the shininess from the roughness and the Fresnel reflectance
+// from the metallic), and an ambient term reduced by the occlusion.
+func (t *tracer) shade(material sdfviewergo.SDFSample, normal, view [3]float32) color.NRGBA {
+	var albedo, f0 [3]float32
+	metallic := clamp01(material.Metallic)
+	for i, c := range material.Color {
+		albedo[i] = clamp01(c)
+		f0[i] = 0.04*(1-metallic) + albedo[i]*metallic
+	}
+	roughness := float32(math.Max(float64(clamp01(material.Roughness)), 0.05))
+	shininess := 2/float32(math.Pow(float64(roughness), 4)) - 2
+
+	var res [3]float32
+	ambient := 0.2 * (1 - clamp01(material.Occlusion))
+	for i := 0; i < 3; i++ {
+		res[i] = ambient * (albedo[i]*(1-metallic) + f0[i]*metallic)
+	}
+	for _, light := range t.lights {
+		nDotL := dot(normal, light.Direction)
+		if nDotL <= 0 {
+			continue
+		}
+		half := normalize(add(light.Direction, view))
+		specular := (shininess + 8) / (8 * math.Pi) * float32(math.Pow(math.Max(float64(dot(normal, half)), 0),
+			float64(shininess)))
+		for i := 0; i < 3; i++ {
+			res[i] += light.Color[i] * nDotL * (albedo[i]*(1-metallic) + f0[i]*specular)
+		}
+	}
+	return color.NRGBA{R: toSRGB(res[0]), G: toSRGB(res[1]), B: toSRGB(res[2]), A: 255}
+}
+
+// toSRGB converts a linear color component to 8-bit sRGB (approximated with a 2.2 gamma).
+func toSRGB(c float32) uint8 {
+	return uint8(math.Round(math.Pow(float64(clamp01(c)), 1/2.2) * 255))
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"image"
+	"math"
+)
+
+// Turntable renders the frames of an animation of a camera orbiting around an SDF (e.g. for previews of changes to
+// parts), optionally sweeping a parameter at the same time.
+type Turntable struct {
+	// Renderer renders each frame (its Camera is ignored).
+	Renderer Renderer
+	// Frames is the number of frames of a full turn (36 if 0).
+	Frames int
+	// Azimuth is the azimuth of the first frame and Elevation is the one of all frames, in degrees (see OrbitCamera).
+	Azimuth, Elevation float32
+	// Sweep animates a parameter if set.
+	Sweep *Sweep
+}
+
+// Sweep animates a float or int parameter of a node of the SDF, from From to To in the first half of the animation
+// and back in the second half, so that it loops.
+type Sweep struct {
+	// SDF is the node of the hierarchy that owns the parameter.
+	SDF sdfviewergo.SDF
+	// ParamID is the ID of the parameter.
+	ParamID uint32
+	// From and To are the values at the start and at the middle of the animation.
+	From, To float32
+}
+
+// Render renders all the frames. The camera keeps the same distance to fit the SDF during the whole sweep, and the
+// swept parameter is restored afterwards.
+func (t Turntable) Render(s sdfviewergo.SDF) ([]*image.NRGBA, error) {
+	frames := t.Frames
+	if frames <= 0 {
+		frames = 36
+	}
+	aabb := s.AABB()
+	if t.Sweep != nil {
+		original, err := t.Sweep.param()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = t.Sweep.SDF.SetParameter(original.ID, original.Value)
+			s.Changed()
+		}()
+		for i := 0; i < frames; i++ {
+			if err = t.Sweep.apply(s, i, frames); err != nil {
+				return nil, err
+			}
+			aabb = mergeAABB(aabb, s.AABB())
+		}
+	}
+
+	res := make([]*image.NRGBA, frames)
+	for i := range res {
+		if t.Sweep != nil {
+			if err := t.Sweep.apply(s, i, frames); err != nil {
+				return nil, err
+			}
+		}
+		camera := OrbitCamera(aabb, t.Azimuth+360*float32(i)/float32(frames), t.Elevation)
+		r := t.Renderer
+		r.Camera = &camera
+		res[i] = r.Render(s)
+	}
+	return res, nil
+}
+
+// param returns the current definition of the swept parameter.
+func (sw *Sweep) param() (sdfviewergo.SDFParam, error) {
+	for _, param := range sw.SDF.Parameters() {
+		if param.ID == sw.ParamID {
+			return param, nil
+		}
+	}
+	return sdfviewergo.SDFParam{}, fmt.Errorf("%s has no parameter %d", sw.SDF.Name(), sw.ParamID)
+}
+
+// apply sets the value of the parameter for the given frame, consuming the change from the root as the app would.
+func (sw *Sweep) apply(root sdfviewergo.SDF, frame, frames int) error {
+	param, err := sw.param()
+	if err != nil {
+		return err
+	}
+	phase := 2 * float32(frame) / float32(frames)
+	if phase > 1 {
+		phase = 2 - phase
+	}
+	v := sw.From + (sw.To-sw.From)*phase
+	var value sdfviewergo.SDFParamValue
+	switch param.Kind.(type) {
+	case sdfviewergo.SDFParamKindFloat:
+		value = v
+	case sdfviewergo.SDFParamKindInt:
+		value = int32(math.Round(float64(v)))
+	default:
+		return errors.New("only float and int parameters can be swept")
+	}
+	if err = sw.SDF.SetParameter(param.ID, value); err != nil {
+		return fmt.Errorf("setting parameter %d of %s: %w", param.ID, sw.SDF.Name(), err)
+	}
+	root.Changed()
+	return nil
+}
+
+func mergeAABB(a, b [2][3]float32) [2][3]float32 {
+	for i := 0; i < 3; i++ {
+		a[0][i] = float32(math.Min(float64(a[0][i]), float64(b[0][i])))
+		a[1][i] = float32(math.Max(float64(a[1][i]), float64(b[1][i])))
+	}
+	return a
+}
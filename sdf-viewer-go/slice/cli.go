@@ -0,0 +1,74 @@
+//go:build !wasm
+
+package slice
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/render"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func init() {
+	sdfviewergo.RegisterCLICommand("slice", sdfviewergo.CLICommand{
+		Usage: "slice [-res N] [-n N] [-png] DIR\n" +
+			"                            Export a stack of Z cross-sections of the scene as SVG (and PNG) files",
+		Run: runCLI,
+	})
+}
+
+func runCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("slice", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	resolution := flags.Int("res", DefaultResolution, "number of cells along the longest side of the slices")
+	count := flags.Int("n", 10, "number of slices, at the centers of equal layers of the bounding box")
+	withPNG := flags.Bool("png", false, "also write PNG images with distance shading")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("slice: expected the output directory")
+	}
+	if *count <= 0 {
+		return errors.New("slice: the number of slices must be positive")
+	}
+	dir := flags.Arg(0)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	aabb := root.AABB()
+	for k := 0; k < *count; k++ {
+		start := time.Now()
+		z := aabb[0][2] + (float32(k)+0.5)*(aabb[1][2]-aabb[0][2])/float32(*count)
+		sl := Sample(root, ZPlane(z), *resolution)
+		path := filepath.Join(dir, fmt.Sprintf("slice_%03d.svg", k))
+		if err := writeSVGFile(path, sl); err != nil {
+			return err
+		}
+		if *withPNG {
+			if err := render.SavePNG(path[:len(path)-len(".svg")]+".png", sl.Image()); err != nil {
+				return err
+			}
+		}
+		_, _ = fmt.Fprintf(stdout, "%s: z=%g (%s)\n", path, z, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+func writeSVGFile(path string, sl *Slice) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return sl.WriteSVG(f)
+}
@@ -0,0 +1,39 @@
+package slice
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Image draws the slice with one pixel per sample (V pointing up): the inside is filled with a color from red at the
+// surface to blue at the deepest point, which shows thin walls at a glance, and the outside is white with gray bands
+// every tenth of that depth.
+func (sl *Slice) Image() *image.NRGBA {
+	depth := float32(0)
+	for _, d := range sl.Distances {
+		depth = float32(math.Max(float64(depth), float64(-d)))
+	}
+	if depth == 0 {
+		depth = sl.Step()
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, sl.Width, sl.Height))
+	for j := 0; j < sl.Height; j++ {
+		for i := 0; i < sl.Width; i++ {
+			d := sl.Distances[j*sl.Width+i]
+			var c color.NRGBA
+			if d < 0 {
+				t := -d / depth
+				c = color.NRGBA{R: uint8(220 * (1 - t)), G: uint8(60 + 60*t), B: uint8(40 + 200*t), A: 255}
+			} else {
+				gray := uint8(255)
+				if int(d/depth*10)%2 == 1 {
+					gray = 235
+				}
+				c = color.NRGBA{R: gray, G: gray, B: gray, A: 255}
+			}
+			img.SetNRGBA(i, sl.Height-1-j, c)
+		}
+	}
+	return img
+}
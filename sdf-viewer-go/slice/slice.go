@@ -0,0 +1,212 @@
+// Package slice samples any sdfviewergo.SDF on 2D planes to extract cross-sections, e.g. for laser cutting or to check
+// wall thicknesses: contours are extracted with marching squares and written as SVG paths, and the distances can be
+// drawn to a shaded PNG image.
+package slice
+
+import (
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+)
+
+// DefaultResolution is the default number of cells along the longest side of a slice.
+const DefaultResolution = 256
+
+// Plane is a plane of the 3D space, with 2D coordinates (u, v) that map to Origin + u*U + v*V.
+type Plane struct {
+	// Origin is the point at (0, 0).
+	Origin [3]float32
+	// U and V are the orthonormal directions of the 2D axes.
+	U, V [3]float32
+}
+
+// ZPlane returns the horizontal plane at the given height, with the X and Y coordinates.
+func ZPlane(z float32) Plane {
+	return Plane{Origin: [3]float32{0, 0, z}, U: [3]float32{1, 0, 0}, V: [3]float32{0, 1, 0}}
+}
+
+// Point returns the 3D point at the given 2D coordinates of the plane.
+func (p Plane) Point(u, v float32) [3]float32 {
+	var res [3]float32
+	for i := 0; i < 3; i++ {
+		res[i] = p.Origin[i] + u*p.U[i] + v*p.V[i]
+	}
+	return res
+}
+
+// Slice is a grid of samples of an SDF on a plane.
+type Slice struct {
+	// Plane is the sampled plane.
+	Plane Plane
+	// Min and Max are the 2D coordinates of the first and last samples.
+	Min, Max [2]float32
+	// Width and Height are the number of samples along U and V.
+	Width, Height int
+	// Distances are the sampled distances, by rows of increasing V.
+	Distances []float32
+}
+
+// Sample samples the SDF on the part of the plane that covers its bounding box, with the given number of cells along
+// the longest side (DefaultResolution if 0) and an extra cell of margin, so that contours are closed.
+func Sample(s sdfviewergo.SDF, plane Plane, resolution int) *Slice {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	// Project the corners of the bounding box to the plane
+	aabb := s.AABB()
+	sl := &Slice{Plane: plane, Min: [2]float32{math.MaxFloat32, math.MaxFloat32},
+		Max: [2]float32{-math.MaxFloat32, -math.MaxFloat32}}
+	for corner := 0; corner < 8; corner++ {
+		var p [3]float32
+		for i := 0; i < 3; i++ {
+			p[i] = aabb[(corner>>i)&1][i] - plane.Origin[i]
+		}
+		for axis, dir := range [2][3]float32{plane.U, plane.V} {
+			coord := p[0]*dir[0] + p[1]*dir[1] + p[2]*dir[2]
+			sl.Min[axis] = float32(math.Min(float64(sl.Min[axis]), float64(coord)))
+			sl.Max[axis] = float32(math.Max(float64(sl.Max[axis]), float64(coord)))
+		}
+	}
+	step := float32(math.Max(float64(sl.Max[0]-sl.Min[0]), float64(sl.Max[1]-sl.Min[1]))) / float32(resolution)
+	if step <= 0 {
+		step = 1
+	}
+	for axis := 0; axis < 2; axis++ {
+		sl.Min[axis] -= step
+		cells := int(math.Ceil(float64((sl.Max[axis] + step - sl.Min[axis]) / step)))
+		sl.Max[axis] = sl.Min[axis] + float32(cells)*step
+	}
+	sl.Width = int(math.Round(float64((sl.Max[0]-sl.Min[0])/step))) + 1
+	sl.Height = int(math.Round(float64((sl.Max[1]-sl.Min[1])/step))) + 1
+
+	points := make([][3]float32, sl.Width*sl.Height)
+	for j := 0; j < sl.Height; j++ {
+		for i := 0; i < sl.Width; i++ {
+			points[j*sl.Width+i] = plane.Point(sl.Min[0]+float32(i)*step, sl.Min[1]+float32(j)*step)
+		}
+	}
+	samples := make([]sdfviewergo.SDFSample, len(points))
+	sdfviewergo.SampleBatch(s, points, true, samples)
+	sl.Distances = make([]float32, len(samples))
+	for i, sample := range samples {
+		sl.Distances[i] = sample.Distance
+	}
+	return sl
+}
+
+// Step returns the distance between two consecutive samples.
+func (sl *Slice) Step() float32 {
+	return (sl.Max[0] - sl.Min[0]) / float32(sl.Width-1)
+}
+
+// Contour is a polyline of the boundary of the cross-section, in 2D coordinates of the plane.
+type Contour struct {
+	// Points are the vertices of the polyline, with the inside of the SDF to their left.
+	Points [][2]float32
+	// Closed is true if the last point connects to the first one (always, unless the SDF crosses the sampled area).
+	Closed bool
+}
+
+// Contours extracts the boundary (distance 0) of the cross-section with marching squares.
+// Outer contours are counter-clockwise and holes are clockwise.
+func (sl *Slice) Contours() []Contour {
+	// The crossings are identified by their edge: 2*sample for the edge along U, 2*sample+1 for the edge along V
+	next := make([]int32, 2*len(sl.Distances)) // The edge at the end of the segment that starts at each edge
+	incoming := make([]bool, len(next))
+	for i := range next {
+		next[i] = -1
+	}
+	for j := 0; j+1 < sl.Height; j++ {
+		for i := 0; i+1 < sl.Width; i++ {
+			sl.cellSegments(i, j, func(from, to int32) {
+				next[from] = to
+				incoming[to] = true
+			})
+		}
+	}
+
+	var res []Contour
+	visited := make([]bool, len(next))
+	walk := func(start int32) {
+		var c Contour
+		edge := start
+		for edge >= 0 && !visited[edge] {
+			visited[edge] = true
+			c.Points = append(c.Points, sl.crossing(edge))
+			edge = next[edge]
+		}
+		c.Closed = edge == start
+		res = append(res, c)
+	}
+	for edge := range next { // Open contours first, so that they are walked from their start
+		if next[edge] >= 0 && !incoming[edge] {
+			walk(int32(edge))
+		}
+	}
+	for edge := range next {
+		if next[edge] >= 0 && !visited[edge] {
+			walk(int32(edge))
+		}
+	}
+	return res
+}
+
+// cellSegments calls segment for each segment of the contour in the cell with the given lower-left sample, from the
+// crossing where the inside is left behind to the one where it is entered (walking counter-clockwise around the cell),
+// so that the inside is on the left of the segments.
+func (sl *Slice) cellSegments(i, j int, segment func(from, to int32)) {
+	corners := [4]int{j*sl.Width + i, j*sl.Width + i + 1, (j+1)*sl.Width + i + 1, (j+1)*sl.Width + i}
+	edges := [4]int32{int32(2 * corners[0]), int32(2*corners[1] + 1), int32(2 * corners[3]), int32(2*corners[0] + 1)}
+	var inside [4]bool
+	for k, corner := range corners {
+		inside[k] = sl.Distances[corner] < 0
+	}
+	var leaving, entering []int // Edges (0 to 3, counter-clockwise) where the inside is left behind or entered
+	for k := 0; k < 4; k++ {
+		if inside[k] && !inside[(k+1)%4] {
+			leaving = append(leaving, k)
+		} else if !inside[k] && inside[(k+1)%4] {
+			entering = append(entering, k)
+		}
+	}
+	if len(leaving) == 0 {
+		return
+	}
+	if len(leaving) == 1 {
+		segment(edges[leaving[0]], edges[entering[0]])
+		return
+	}
+	// Saddle: if the center is inside, the segments cut the outside corners off, connecting each leaving crossing to
+	// the next entering one; otherwise, they cut the inside corners off, connecting it to the previous entering one
+	center := (sl.Distances[corners[0]] + sl.Distances[corners[1]] + sl.Distances[corners[2]] +
+		sl.Distances[corners[3]]) / 4
+	for _, l := range leaving {
+		for _, e := range entering {
+			isNext := e == (l+1)%4 || e == (l+2)%4
+			if isNext == (center < 0) {
+				segment(edges[l], edges[e])
+			}
+		}
+	}
+}
+
+// crossing returns the point of the edge where the distance is 0, by linear interpolation.
+func (sl *Slice) crossing(edge int32) [2]float32 {
+	a := int(edge / 2)
+	b := a + 1
+	if edge%2 == 1 {
+		b = a + sl.Width
+	}
+	da, db := sl.Distances[a], sl.Distances[b]
+	t := float32(0.5)
+	if da != db {
+		t = da / (da - db)
+	}
+	step := sl.Step()
+	i, j := float32(a%sl.Width), float32(a/sl.Width)
+	if edge%2 == 0 {
+		i += t
+	} else {
+		j += t
+	}
+	return [2]float32{sl.Min[0] + i*step, sl.Min[1] + j*step}
+}
@@ -0,0 +1,104 @@
+package slice
+
+import (
+	"bytes"
+	"encoding/xml"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"math"
+	"strings"
+	"testing"
+)
+
+// ring is a torus around the Z axis, with a square cross-section.
+type ring struct {
+	inner, outer, halfHeight float32
+}
+
+func (r *ring) AABB() [2][3]float32 {
+	return [2][3]float32{{-r.outer, -r.outer, -r.halfHeight}, {r.outer, r.outer, r.halfHeight}}
+}
+
+func (r *ring) Sample(p [3]float32, _ bool) sdfviewergo.SDFSample {
+	radial := float32(math.Hypot(float64(p[0]), float64(p[1])))
+	d := math.Max(float64(radial-r.outer), float64(r.inner-radial))
+	d = math.Max(d, math.Abs(float64(p[2]))-float64(r.halfHeight))
+	return sdfviewergo.SDFSample{Distance: float32(d)}
+}
+
+func (r *ring) Children() []sdfviewergo.SDF                          { return nil }
+func (r *ring) Name() string                                         { return "ring" }
+func (r *ring) Parameters() []sdfviewergo.SDFParam                   { return nil }
+func (r *ring) SetParameter(uint32, sdfviewergo.SDFParamValue) error { return nil }
+func (r *ring) Changed() sdfviewergo.ChangedAABB                     { return sdfviewergo.ChangedAABB{} }
+
+// signedArea returns the area enclosed by the polyline (positive if counter-clockwise).
+func signedArea(points [][2]float32) float64 {
+	area := 0.0
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += float64(p[0]*q[1]-q[0]*p[1]) / 2
+	}
+	return area
+}
+
+func TestContours(t *testing.T) {
+	r := &ring{inner: 1, outer: 2, halfHeight: 0.5}
+	sl := Sample(r, ZPlane(0), 64)
+	contours := sl.Contours()
+	if len(contours) != 2 {
+		t.Fatalf("expected the outer and inner contours, got %d", len(contours))
+	}
+	var areas []float64
+	for _, c := range contours {
+		if !c.Closed {
+			t.Fatal("expected closed contours")
+		}
+		areas = append(areas, signedArea(c.Points))
+	}
+	if areas[0] < areas[1] {
+		areas[0], areas[1] = areas[1], areas[0]
+	}
+	// The outer circle is counter-clockwise and the hole is clockwise
+	if math.Abs(areas[0]-4*math.Pi) > 0.05 || math.Abs(areas[1]+math.Pi) > 0.05 {
+		t.Fatalf("unexpected areas: %v (expected %v and %v)", areas, 4*math.Pi, -math.Pi)
+	}
+
+	if c := Sample(r, ZPlane(1), 64).Contours(); len(c) != 0 {
+		t.Fatalf("expected no contours above the ring, got %d", len(c))
+	}
+
+	// A vertical plane through the axis cuts two squares
+	side := Sample(r, Plane{U: [3]float32{1, 0, 0}, V: [3]float32{0, 0, 1}}, 64).Contours()
+	if len(side) != 2 || math.Abs(signedArea(side[0].Points)-1) > 0.01 {
+		t.Fatalf("expected 2 squares of area 1, got %d contours", len(side))
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	sl := Sample(&ring{inner: 1, outer: 2, halfHeight: 0.5}, ZPlane(0), 32)
+	var buf bytes.Buffer
+	if err := sl.WriteSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		Width string `xml:"width,attr"`
+		Paths []struct {
+			D string `xml:"d,attr"`
+		} `xml:"g>path"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatal(err)
+	}
+	if len(svg.Paths) != 2 || !strings.HasSuffix(svg.Paths[0].D, " Z") || !strings.HasSuffix(svg.Width, "mm") {
+		t.Fatalf("unexpected SVG:\n%s", buf.String())
+	}
+
+	img := sl.Image()
+	if img.Bounds().Dx() != sl.Width || img.Bounds().Dy() != sl.Height {
+		t.Fatalf("unexpected image size %v", img.Bounds())
+	}
+	center, wall := img.NRGBAAt(sl.Width/2, sl.Height/2), img.NRGBAAt(sl.Width/2, sl.Height/2+int(1.5/sl.Step()))
+	if center.R != center.B || wall.R == wall.B {
+		t.Fatalf("expected the hole to be gray and the wall colored: %v and %v", center, wall)
+	}
+}
@@ -0,0 +1,40 @@
+package slice
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteSVG writes the contours of the slice as SVG paths, in millimeters (one unit of the SDF) and with the V axis
+// pointing up, for laser cutters and vector editors.
+func (sl *Slice) WriteSVG(w io.Writer) error {
+	width, height := sl.Max[0]-sl.Min[0], sl.Max[1]-sl.Min[1]
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s">
+ <g fill="none" stroke="black" stroke-width="%s">
+`, formatFloat(width), formatFloat(height), formatFloat(width), formatFloat(height), formatFloat(sl.Step()/4))
+	for _, c := range sl.Contours() {
+		_, _ = bw.WriteString(`  <path d="`)
+		for i, p := range c.Points {
+			if i == 0 {
+				_, _ = bw.WriteString("M")
+			} else {
+				_, _ = bw.WriteString(" L")
+			}
+			_, _ = fmt.Fprintf(bw, "%s %s", formatFloat(p[0]-sl.Min[0]), formatFloat(sl.Max[1]-p[1]))
+		}
+		if c.Closed {
+			_, _ = bw.WriteString(" Z")
+		}
+		_, _ = bw.WriteString("\"/>\n")
+	}
+	_, _ = bw.WriteString(" </g>\n</svg>\n")
+	return bw.Flush()
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
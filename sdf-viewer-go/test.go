@@ -3,15 +3,20 @@ package sdf_viewer_go
 import (
 	"strconv"
 	"testing"
+	"unsafe"
 )
 
-func TestImpl(_ *testing.T, s SDF) {
+func TestImpl(t *testing.T, s SDF) {
 	// Configure the root SDF
 	SetRootSDF(s)
 
 	// Test that operations on root and ALL descendant nodes don't panic
-	for childID := range availableSDFs {
+	for _, childID := range registeredIDs() {
 		testSubSDF(childID, 1)
+		if lastError != nil {
+			t.Errorf("%s(%d) failed: %s", lastError.Export, lastError.SDFID, lastError.Message)
+			lastError = nil
+		}
 	}
 
 	// TODO: More and better tests
@@ -22,7 +27,7 @@ func BenchmarkImpl(t *testing.B, s SDF) {
 	SetRootSDF(s)
 
 	// Test that operations on root and ALL descendant nodes don't panic
-	for childID := range availableSDFs {
+	for _, childID := range registeredIDs() {
 		t.Run("Child#"+strconv.Itoa(int(childID)), func(b *testing.B) {
 			testSubSDF(childID, b.N)
 		})
@@ -31,10 +36,22 @@ func BenchmarkImpl(t *testing.B, s SDF) {
 	// TODO: More and better tests
 }
 
+// registeredIDs returns a snapshot of the registered IDs, as the registry may change while testing them.
+func registeredIDs() []uint32 {
+	ids := make([]uint32, 0, len(registry.nodes))
+	for sdfID := range registry.nodes {
+		ids = append(ids, sdfID)
+	}
+	return ids
+}
+
 func testSubSDF(childID uint32, times int) {
 	for i := 0; i < times; i++ {
 		bounding_box(childID)
 		sample(childID, [3]float32{0, 0, 0}, false)
+		points := [][3]float32{{0, 0, 0}, {0.5, 0.5, 0.5}}
+		samples := make([]SDFSample, len(points))
+		sample_batch(childID, toWasmPointer(unsafe.Pointer(&points[0])), uint32(len(points)), false, toWasmPointer(unsafe.Pointer(&samples[0])))
 		children(childID)
 		//for _, child := range getSDFOrPanic(childID).Children() {
 		//	// NOTE: Printing with %v is not supported by tinygo: https://github.com/tinygo-org/tinygo/issues/2983
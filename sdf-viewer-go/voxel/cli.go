@@ -0,0 +1,39 @@
+//go:build !wasm
+
+package voxel
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"time"
+)
+
+func init() {
+	sdfviewergo.RegisterCLICommand("voxel", sdfviewergo.CLICommand{
+		Usage: "voxel [-res N] [-band N] FILE\n" +
+			"                            Export a voxel grid of the scene (.raw with a .json header, .vox or .sdfnb)",
+		Run: runCLI,
+	})
+}
+
+func runCLI(root sdfviewergo.SDF, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("voxel", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	resolution := flags.Int("res", DefaultResolution, "number of voxels along the longest axis of the bounding box")
+	band := flags.Float64("band", 3, "half-width of the narrow band of .sdfnb files, in voxels")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("voxel: expected the output file")
+	}
+	start := time.Now()
+	if err := ExportFile(flags.Arg(0), root, *resolution, float32(*band)); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "%s (%s)\n", flags.Arg(0), time.Since(start).Round(time.Millisecond))
+	return nil
+}
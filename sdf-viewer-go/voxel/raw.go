@@ -0,0 +1,49 @@
+package voxel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+)
+
+// RawHeader describes a raw grid written by WriteRaw, as JSON.
+type RawHeader struct {
+	// Format is the type of each value: "float32le" (little-endian IEEE 754 single precision).
+	Format string `json:"format"`
+	// Size is the number of voxels along each axis.
+	Size [3]int `json:"size"`
+	// Origin is the minimum corner of the first voxel.
+	Origin [3]float32 `json:"origin"`
+	// VoxelSize is the side of each voxel.
+	VoxelSize float32 `json:"voxelSize"`
+	// Order is the order of the values: "xyz" means that X varies fastest, then Y, then Z.
+	Order string `json:"order"`
+}
+
+// Header returns the header of the raw grid.
+func (g *Grid) Header() RawHeader {
+	return RawHeader{Format: "float32le", Size: g.Size, Origin: g.Min, VoxelSize: g.VoxelSize, Order: "xyz"}
+}
+
+// WriteRaw writes the distances of the grid as raw little-endian float32 values, without any header (see
+// WriteRawHeader).
+func (g *Grid) WriteRaw(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	var buf [4]byte
+	for _, sample := range g.Samples {
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(sample.Distance))
+		if _, err := bw.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteRawHeader writes the header that describes the output of WriteRaw, as indented JSON.
+func (g *Grid) WriteRawHeader(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g.Header())
+}
@@ -0,0 +1,209 @@
+package voxel
+
+import (
+	"bufio"
+	"encoding/binary"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// BlockSize is the number of voxels along each axis of the blocks of a SparseGrid.
+const BlockSize = 8
+
+// blockVoxels is the number of voxels of a block.
+const blockVoxels = BlockSize * BlockSize * BlockSize
+
+// SparseGrid is a narrow band of samples around the surface of an SDF, in blocks of voxels like the leaf nodes of
+// OpenVDB: only the blocks that are near the surface store voxels, the blocks that are deep inside are stored as
+// inside tiles, and the rest are outside.
+type SparseGrid struct {
+	// Min is the minimum corner of the first voxel of the first block.
+	Min [3]float32
+	// VoxelSize is the side of each voxel.
+	VoxelSize float32
+	// Size is the number of voxels along each axis (a multiple of BlockSize).
+	Size [3]int
+	// Band is the half-width of the narrow band: voxels are active if the absolute distance is smaller, and the
+	// distances are clamped to it.
+	Band float32
+	// Blocks are the blocks that are not outside, in order of increasing Z, Y and X.
+	Blocks []Block
+}
+
+// Block is a cube of BlockSize^3 voxels of a SparseGrid.
+type Block struct {
+	// Origin is the index of the first voxel of the block.
+	Origin [3]int
+	// Inside is true for tiles deep inside the SDF, which have no voxels (all their distances are -Band).
+	Inside bool
+	// Active has a bit set for each voxel in the narrow band, in the order of Distances.
+	Active [blockVoxels / 64]uint64
+	// Distances are the clamped distances of all the voxels, with X varying fastest, then Y, then Z.
+	Distances []float32
+	// Samples are the full samples of the active voxels, in the same order.
+	Samples []sdfviewergo.SDFSample
+}
+
+// SampleSparse samples the SDF in a narrow band of the given half-width in voxels (3 if 0, at least 1) around its
+// surface, with the given number of voxels along the longest axis of the bounding box (DefaultResolution if 0).
+//
+// Blocks are skipped from the distance at their center, which assumes that the SDF does not overestimate distances.
+func SampleSparse(s sdfviewergo.SDF, resolution int, band float32) *SparseGrid {
+	if band <= 0 {
+		band = 3
+	}
+	band = float32(math.Max(1, float64(band)))
+	dense := newGrid(s.AABB(), resolution, int(math.Ceil(float64(band))))
+	g := &SparseGrid{Min: dense.Min, VoxelSize: dense.VoxelSize, Band: band * dense.VoxelSize}
+	var blocks [3]int
+	for i := 0; i < 3; i++ {
+		blocks[i] = (dense.Size[i] + BlockSize - 1) / BlockSize
+		g.Size[i] = blocks[i] * BlockSize
+	}
+
+	// Classify the blocks from their centers, in one batch
+	var origins [][3]int
+	var centers [][3]float32
+	for z := 0; z < blocks[2]; z++ {
+		for y := 0; y < blocks[1]; y++ {
+			for x := 0; x < blocks[0]; x++ {
+				origin := [3]int{x * BlockSize, y * BlockSize, z * BlockSize}
+				origins = append(origins, origin)
+				var center [3]float32
+				for i := 0; i < 3; i++ {
+					center[i] = g.Min[i] + (float32(origin[i])+BlockSize/2)*g.VoxelSize
+				}
+				centers = append(centers, center)
+			}
+		}
+	}
+	centerSamples := make([]sdfviewergo.SDFSample, len(centers))
+	sdfviewergo.SampleBatch(s, centers, true, centerSamples)
+	reach := float32(math.Sqrt(3)) * BlockSize / 2 * g.VoxelSize // From the center to the corners of a block
+	for i, sample := range centerSamples {
+		if sample.Distance > reach+g.Band {
+			continue
+		}
+		if sample.Distance < -reach-g.Band {
+			g.Blocks = append(g.Blocks, Block{Origin: origins[i], Inside: true})
+			continue
+		}
+		g.Blocks = append(g.Blocks, g.sampleBlock(s, origins[i]))
+	}
+
+	// Blocks without active voxels are tiles too
+	blocksNear := g.Blocks[:0]
+	for _, b := range g.Blocks {
+		if !b.Inside && len(b.Samples) == 0 {
+			if b.Distances[0] > 0 {
+				continue
+			}
+			b = Block{Origin: b.Origin, Inside: true}
+		}
+		blocksNear = append(blocksNear, b)
+	}
+	g.Blocks = blocksNear
+	return g
+}
+
+// sampleBlock samples all the voxels of the block at the given origin.
+func (g *SparseGrid) sampleBlock(s sdfviewergo.SDF, origin [3]int) Block {
+	points := make([][3]float32, 0, blockVoxels)
+	for z := 0; z < BlockSize; z++ {
+		for y := 0; y < BlockSize; y++ {
+			for x := 0; x < BlockSize; x++ {
+				points = append(points, [3]float32{
+					g.Min[0] + (float32(origin[0]+x)+0.5)*g.VoxelSize,
+					g.Min[1] + (float32(origin[1]+y)+0.5)*g.VoxelSize,
+					g.Min[2] + (float32(origin[2]+z)+0.5)*g.VoxelSize,
+				})
+			}
+		}
+	}
+	samples := make([]sdfviewergo.SDFSample, len(points))
+	sdfviewergo.SampleBatch(s, points, false, samples)
+	b := Block{Origin: origin, Distances: make([]float32, blockVoxels)}
+	for i, sample := range samples {
+		b.Distances[i] = float32(math.Max(-float64(g.Band), math.Min(float64(g.Band), float64(sample.Distance))))
+		if math.Abs(float64(sample.Distance)) < float64(g.Band) {
+			b.Active[i/64] |= 1 << (i % 64)
+			b.Samples = append(b.Samples, sample)
+		}
+	}
+	return b
+}
+
+// ActiveVoxels returns the number of voxels in the narrow band.
+func (g *SparseGrid) ActiveVoxels() int {
+	res := 0
+	for _, b := range g.Blocks {
+		for _, mask := range b.Active {
+			res += bits.OnesCount64(mask)
+		}
+	}
+	return res
+}
+
+// sparseMagic starts the files written by WriteSparse, with the version of the format.
+const sparseMagic = "SDFNB\x00\x00\x01"
+
+// WriteSparse writes the grid in a simple little-endian binary format:
+//
+//	magic        "SDFNB\x00\x00\x01"
+//	size         3 x uint32 (voxels along each axis)
+//	blockSize    uint32 (8)
+//	min          3 x float32 (minimum corner of the first voxel)
+//	voxelSize    float32
+//	band         float32 (the distance of the voxels outside the blocks)
+//	blockCount   uint32
+//	blocks       blockCount x:
+//	  origin     3 x uint32 (index of the first voxel)
+//	  kind       uint8 (0: inside tile with all distances -band, 1: voxels)
+//	  if kind is 1:
+//	    active     8 x uint64 (bit i%64 of word i/64 is set if voxel i is in the narrow band)
+//	    distances  512 x float32 (X varies fastest, then Y, then Z)
+//	    materials  6 x uint8 for each active voxel: color RGB, metallic, roughness and occlusion
+func (g *SparseGrid) WriteSparse(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	buf := []byte(sparseMagic)
+	for i := 0; i < 3; i++ {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Size[i]))
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, BlockSize)
+	for i := 0; i < 3; i++ {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(g.Min[i]))
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(g.VoxelSize))
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(g.Band))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Blocks)))
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	for _, b := range g.Blocks {
+		buf = buf[:0]
+		for i := 0; i < 3; i++ {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(b.Origin[i]))
+		}
+		if b.Inside {
+			buf = append(buf, 0)
+		} else {
+			buf = append(buf, 1)
+			for _, mask := range b.Active {
+				buf = binary.LittleEndian.AppendUint64(buf, mask)
+			}
+			for _, d := range b.Distances {
+				buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(d))
+			}
+			for _, sample := range b.Samples {
+				buf = append(buf, unorm8(sample.Color[0]), unorm8(sample.Color[1]), unorm8(sample.Color[2]),
+					unorm8(sample.Metallic), unorm8(sample.Roughness), unorm8(sample.Occlusion))
+			}
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
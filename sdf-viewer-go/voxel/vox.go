@@ -0,0 +1,132 @@
+package voxel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MaxVOXSize is the maximum number of voxels along each axis of a MagicaVoxel model.
+const MaxVOXSize = 256
+
+// WriteVOX writes the voxels inside the SDF (distance < 0) as a MagicaVoxel .vox model, with a palette of up to 255
+// colors quantized from the colors of the samples.
+func (g *Grid) WriteVOX(w io.Writer) error {
+	for i := 0; i < 3; i++ {
+		if g.Size[i] > MaxVOXSize {
+			return fmt.Errorf("the grid is too big for a .vox model: %v voxels (max %d per axis)", g.Size,
+				MaxVOXSize)
+		}
+	}
+	var voxels []byte // x, y, z, color index
+	var bins []int    // Of each voxel
+	histogram := map[int]*colorBin{}
+	for z := 0; z < g.Size[2]; z++ {
+		for y := 0; y < g.Size[1]; y++ {
+			for x := 0; x < g.Size[0]; x++ {
+				sample := g.Samples[g.Index(x, y, z)]
+				if sample.Distance >= 0 {
+					continue
+				}
+				r, gr, b := unorm8(sample.Color[0]), unorm8(sample.Color[1]), unorm8(sample.Color[2])
+				bin := int(r>>4)<<8 | int(gr>>4)<<4 | int(b>>4)
+				if histogram[bin] == nil {
+					histogram[bin] = &colorBin{bin: bin}
+				}
+				histogram[bin].add(r, gr, b)
+				voxels = append(voxels, byte(x), byte(y), byte(z), 0)
+				bins = append(bins, bin)
+			}
+		}
+	}
+	palette, binIndex := voxPalette(histogram)
+	for i, bin := range bins {
+		voxels[4*i+3] = binIndex[bin]
+	}
+
+	// Chunks: MAIN with SIZE, XYZI and RGBA children
+	size := make([]byte, 12)
+	for i := 0; i < 3; i++ {
+		binary.LittleEndian.PutUint32(size[4*i:], uint32(g.Size[i]))
+	}
+	xyzi := binary.LittleEndian.AppendUint32(nil, uint32(len(bins)))
+	xyzi = append(xyzi, voxels...)
+	rgba := make([]byte, 4*256)
+	for i, c := range palette { // Entry i is color index i+1
+		copy(rgba[4*i:], []byte{c[0], c[1], c[2], 255})
+	}
+	var children []byte
+	children = appendVOXChunk(children, "SIZE", size, nil)
+	children = appendVOXChunk(children, "XYZI", xyzi, nil)
+	children = appendVOXChunk(children, "RGBA", rgba, nil)
+
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("VOX ")
+	_, _ = bw.Write(binary.LittleEndian.AppendUint32(nil, 150))
+	_, _ = bw.Write(appendVOXChunk(nil, "MAIN", nil, children))
+	return bw.Flush()
+}
+
+func appendVOXChunk(dst []byte, id string, content, children []byte) []byte {
+	dst = append(dst, id...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(content)))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(children)))
+	dst = append(dst, content...)
+	return append(dst, children...)
+}
+
+// colorBin accumulates the colors that quantize to the same 4-bit per channel bin.
+type colorBin struct {
+	bin   int
+	count int
+	sum   [3]int
+}
+
+func (c *colorBin) add(r, g, b uint8) {
+	c.count++
+	c.sum[0] += int(r)
+	c.sum[1] += int(g)
+	c.sum[2] += int(b)
+}
+
+func (c *colorBin) average() [3]uint8 {
+	return [3]uint8{uint8(c.sum[0] / c.count), uint8(c.sum[1] / c.count), uint8(c.sum[2] / c.count)}
+}
+
+// voxPalette returns the average colors of the 255 most used bins, and the color index (from 1) of each bin: the
+// less used bins map to the nearest color of the palette.
+func voxPalette(histogram map[int]*colorBin) ([][3]uint8, map[int]uint8) {
+	bins := make([]*colorBin, 0, len(histogram))
+	for _, bin := range histogram {
+		bins = append(bins, bin)
+	}
+	sort.Slice(bins, func(i, j int) bool {
+		if bins[i].count != bins[j].count {
+			return bins[i].count > bins[j].count
+		}
+		return bins[i].bin < bins[j].bin
+	})
+	var palette [][3]uint8
+	binIndex := map[int]uint8{}
+	for i, bin := range bins {
+		if i < 255 {
+			palette = append(palette, bin.average())
+			binIndex[bin.bin] = uint8(i + 1)
+			continue
+		}
+		c, best, bestDistance := bin.average(), 0, -1
+		for j, p := range palette {
+			distance := 0
+			for k := 0; k < 3; k++ {
+				distance += (int(c[k]) - int(p[k])) * (int(c[k]) - int(p[k]))
+			}
+			if bestDistance < 0 || distance < bestDistance {
+				best, bestDistance = j, distance
+			}
+		}
+		binIndex[bin.bin] = uint8(best + 1)
+	}
+	return palette, binIndex
+}
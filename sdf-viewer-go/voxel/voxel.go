@@ -0,0 +1,121 @@
+// Package voxel samples any sdfviewergo.SDF on voxel grids and writes them to volume formats, for tools (e.g. game
+// engines) that use distance fields directly instead of meshes: a dense raw float32 grid with a JSON header,
+// MagicaVoxel .vox models colored by the samples, and a sparse narrow-band format that only stores the voxels near the
+// surface.
+package voxel
+
+import (
+	"fmt"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultResolution is the number of voxels along the longest axis of the bounding box used by default.
+const DefaultResolution = 128
+
+// Grid is a dense grid of samples of an SDF at the centers of cubic voxels.
+type Grid struct {
+	// Min is the minimum corner of the first voxel.
+	Min [3]float32
+	// VoxelSize is the side of each voxel.
+	VoxelSize float32
+	// Size is the number of voxels along each axis.
+	Size [3]int
+	// Samples are the samples at the center of each voxel, with X varying fastest, then Y, then Z (see Index).
+	Samples []sdfviewergo.SDFSample
+}
+
+// Sample samples the SDF on a grid that covers its bounding box, with the given number of voxels along the longest
+// axis (DefaultResolution if 0). If distanceOnly is true, only the distances of the samples are set.
+func Sample(s sdfviewergo.SDF, resolution int, distanceOnly bool) *Grid {
+	g := newGrid(s.AABB(), resolution, 0)
+	g.Samples = make([]sdfviewergo.SDFSample, g.Size[0]*g.Size[1]*g.Size[2])
+	layer := g.Size[0] * g.Size[1]
+	points := make([][3]float32, layer)
+	for z := 0; z < g.Size[2]; z++ { // In batches of a layer, to bound the memory of the points
+		for y := 0; y < g.Size[1]; y++ {
+			for x := 0; x < g.Size[0]; x++ {
+				points[y*g.Size[0]+x] = g.Center(x, y, z)
+			}
+		}
+		sdfviewergo.SampleBatch(s, points, distanceOnly, g.Samples[z*layer:(z+1)*layer])
+	}
+	return g
+}
+
+// ExportFile samples the SDF and writes it to the given path, in the format of its extension: .raw (with the header
+// next to it, as .json), .vox or .sdfnb (the sparse narrow band of WriteSparse, with the given band in voxels).
+func ExportFile(path string, s sdfviewergo.SDF, resolution int, band float32) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".raw":
+		g := Sample(s, resolution, true)
+		if err := writeFile(path, g.WriteRaw); err != nil {
+			return err
+		}
+		return writeFile(path[:len(path)-len(ext)]+".json", g.WriteRawHeader)
+	case ".vox":
+		g := Sample(s, resolution, false)
+		return writeFile(path, g.WriteVOX)
+	case ".sdfnb":
+		return writeFile(path, SampleSparse(s, resolution, band).WriteSparse)
+	default:
+		return fmt.Errorf("unsupported voxel format %q (expected .raw, .vox or .sdfnb)", ext)
+	}
+}
+
+func writeFile(path string, write func(io.Writer) error) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return write(f)
+}
+
+// newGrid returns an empty grid that covers the bounding box, with the given number of voxels along its longest axis
+// and of extra voxels on each side.
+func newGrid(aabb [2][3]float32, resolution, margin int) *Grid {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	longest := float32(0)
+	for i := 0; i < 3; i++ {
+		longest = float32(math.Max(float64(longest), float64(aabb[1][i]-aabb[0][i])))
+	}
+	g := &Grid{VoxelSize: longest / float32(resolution)}
+	if g.VoxelSize <= 0 {
+		g.VoxelSize = 1 // Empty bounding box: a single voxel
+	}
+	for i := 0; i < 3; i++ {
+		g.Size[i] = int(math.Max(1, math.Ceil(float64((aabb[1][i]-aabb[0][i])/g.VoxelSize)))) + 2*margin
+		g.Min[i] = aabb[0][i] - float32(margin)*g.VoxelSize
+	}
+	return g
+}
+
+// Index returns the index of the given voxel in Samples.
+func (g *Grid) Index(x, y, z int) int {
+	return (z*g.Size[1]+y)*g.Size[0] + x
+}
+
+// Center returns the position of the center of the given voxel.
+func (g *Grid) Center(x, y, z int) [3]float32 {
+	return [3]float32{g.Min[0] + (float32(x)+0.5)*g.VoxelSize, g.Min[1] + (float32(y)+0.5)*g.VoxelSize,
+		g.Min[2] + (float32(z)+0.5)*g.VoxelSize}
+}
+
+func clamp01(v float32) float32 {
+	return float32(math.Min(math.Max(float64(v), 0), 1))
+}
+
+func unorm8(v float32) uint8 {
+	return uint8(math.Round(float64(clamp01(v)) * 255))
+}
@@ -0,0 +1,107 @@
+package voxel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	sdfviewergo "github.com/Yeicor/sdf-viewer-go/sdf-viewer-go"
+	"github.com/Yeicor/sdf-viewer-go/sdf-viewer-go/internal/testsdf"
+	"math"
+	"testing"
+)
+
+// orange is the material of the test spheres.
+var orange = sdfviewergo.SDFSample{Color: [3]float32{1, 0.5, 0}, Roughness: 1}
+
+func TestRaw(t *testing.T) {
+	g := Sample(&testsdf.Sphere{Radius: 1, Material: orange}, 16, true)
+	if g.Size != [3]int{16, 16, 16} || g.VoxelSize != 0.125 {
+		t.Fatalf("unexpected grid: %v voxels of %v", g.Size, g.VoxelSize)
+	}
+	var raw, header bytes.Buffer
+	if err := g.WriteRaw(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.WriteRawHeader(&header); err != nil {
+		t.Fatal(err)
+	}
+	var h RawHeader
+	if err := json.Unmarshal(header.Bytes(), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h != g.Header() || raw.Len() != 4*16*16*16 {
+		t.Fatalf("unexpected header %+v for %d bytes", h, raw.Len())
+	}
+	// The voxel at (8, 8, 8) has its center at (0.0625, 0.0625, 0.0625)
+	d := math.Float32frombits(binary.LittleEndian.Uint32(raw.Bytes()[4*g.Index(8, 8, 8):]))
+	if expected := float32(math.Sqrt(3)*0.0625 - 1); math.Abs(float64(d-expected)) > 1e-6 {
+		t.Fatalf("expected a distance of %v, got %v", expected, d)
+	}
+}
+
+func TestVOX(t *testing.T) {
+	g := Sample(&testsdf.Sphere{Radius: 1, Material: orange}, 32, false)
+	var buf bytes.Buffer
+	if err := g.WriteVOX(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if string(data[:4]) != "VOX " || string(data[8:12]) != "MAIN" {
+		t.Fatal("expected a VOX header and a MAIN chunk")
+	}
+	// Children of MAIN: SIZE (12 bytes), XYZI and RGBA
+	chunks := map[string][]byte{}
+	for rest := data[20:]; len(rest) > 0; {
+		size := binary.LittleEndian.Uint32(rest[4:])
+		chunks[string(rest[:4])] = rest[12 : 12+size]
+		rest = rest[12+size:]
+	}
+	voxels := int(binary.LittleEndian.Uint32(chunks["XYZI"]))
+	volume := 4.0 / 3 * math.Pi / math.Pow(float64(g.VoxelSize), 3)
+	if math.Abs(float64(voxels)-volume) > 0.05*volume {
+		t.Fatalf("expected about %.0f voxels, got %d", volume, voxels)
+	}
+	if index := chunks["XYZI"][7]; index != 1 || !bytes.Equal(chunks["RGBA"][:4], []byte{255, 128, 0, 255}) {
+		t.Fatalf("expected all voxels to use the first color of the palette, got %d and %v", index,
+			chunks["RGBA"][:4])
+	}
+
+	big := &Grid{Size: [3]int{MaxVOXSize + 1, 1, 1}, Samples: make([]sdfviewergo.SDFSample, MaxVOXSize+1)}
+	if err := big.WriteVOX(&bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error for a grid too big for a .vox model")
+	}
+}
+
+func TestSparse(t *testing.T) {
+	g := SampleSparse(&testsdf.Sphere{Radius: 1, Material: orange}, 64, 2)
+	insideTiles := 0
+	for _, b := range g.Blocks {
+		if b.Inside {
+			insideTiles++
+			continue
+		}
+		for i, d := range b.Distances {
+			active := b.Active[i/64]&(1<<(i%64)) != 0
+			if active != (math.Abs(float64(d)) < float64(g.Band)) {
+				t.Fatalf("voxel %d of block %v: active is %v for a distance of %v", i, b.Origin, active, d)
+			}
+		}
+	}
+	if insideTiles == 0 {
+		t.Fatal("expected tiles inside the sphere")
+	}
+	// The narrow band is a shell of 4 voxels of thickness
+	shell := 4 * math.Pi * 4 * math.Pow(float64(g.VoxelSize), -2)
+	if active := g.ActiveVoxels(); math.Abs(float64(active)-shell) > 0.05*shell {
+		t.Fatalf("expected about %.0f active voxels, got %d", shell, active)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteSparse(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := 48 + 13*len(g.Blocks) + (64+4*blockVoxels)*(len(g.Blocks)-insideTiles) + 6*g.ActiveVoxels()
+	if buf.Len() != expected || string(buf.Bytes()[:8]) != sparseMagic {
+		t.Fatalf("expected %d bytes, got %d", expected, buf.Len())
+	}
+}
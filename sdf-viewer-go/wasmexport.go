@@ -0,0 +1,97 @@
+//go:build wasip1 && !tinygo
+
+package sdf_viewer_go
+
+import "unsafe"
+
+// Exports for the standard Go compiler (GOOS=wasip1 GOARCH=wasm, Go 1.24+), which does not understand TinyGo's
+// `//export` directives. Build with `go build -buildmode=c-shared` to get a reactor module like the TinyGo one.
+// The signatures mirror the ones TinyGo generates for exports.go, so the SDF Viewer app can load both:
+// aggregates passed by value are flattened and returned structs are pointers into the linear memory.
+
+//go:wasmexport abi_version
+func wasmABIVersion() uint32 {
+	return abi_version()
+}
+
+//go:wasmexport capabilities
+func wasmCapabilities() uint32 {
+	return capabilities()
+}
+
+//go:wasmexport bounding_box
+func wasmBoundingBox(sdfID uint32) unsafe.Pointer {
+	return unsafe.Pointer(bounding_box(sdfID))
+}
+
+//go:wasmexport sample
+func wasmSample(sdfID uint32, pointX, pointY, pointZ float32, distanceOnly bool) unsafe.Pointer {
+	return unsafe.Pointer(sample(sdfID, [3]float32{pointX, pointY, pointZ}, distanceOnly))
+}
+
+//go:wasmexport sample_batch
+func wasmSampleBatch(sdfID uint32, pointsPtr wasmPointer, count uint32, distanceOnly bool, samplesPtr wasmPointer) {
+	sample_batch(sdfID, pointsPtr, count, distanceOnly, samplesPtr)
+}
+
+//go:wasmexport children
+func wasmChildren(sdfID uint32) unsafe.Pointer {
+	return unsafe.Pointer(children(sdfID))
+}
+
+//go:wasmexport name
+func wasmName(sdfID uint32) unsafe.Pointer {
+	return unsafe.Pointer(name(sdfID))
+}
+
+//go:wasmexport parameters
+func wasmParameters(sdfID uint32) unsafe.Pointer {
+	return unsafe.Pointer(parameters(sdfID))
+}
+
+//go:wasmexport set_parameter
+func wasmSetParameter(sdfID, paramID, paramKindID uint32, paramArg1 wasmWord, paramArg2 uint32) unsafe.Pointer {
+	return unsafe.Pointer(set_parameter(sdfID, paramID, paramKindID, paramArg1, paramArg2))
+}
+
+//go:wasmexport changed
+func wasmChanged(sdfID uint32) unsafe.Pointer {
+	return unsafe.Pointer(changed(sdfID))
+}
+
+//go:wasmexport last_error
+func wasmLastError() unsafe.Pointer {
+	return unsafe.Pointer(last_error())
+}
+
+//go:wasmexport returned_generation
+func wasmReturnedGeneration() uint32 {
+	return returned_generation()
+}
+
+//go:wasmexport release
+func wasmRelease(generation uint32) {
+	release(generation)
+}
+
+// wasmAllocations keeps memory handed to the host alive until it is freed, as the host may write to it at any time.
+var wasmAllocations = map[wasmPointer][]byte{}
+
+// malloc is exported by TinyGo's runtime, and the host may use it to write values (e.g. string parameters) into the
+// linear memory of the module.
+//
+//go:wasmexport malloc
+func wasmMalloc(size uint32) unsafe.Pointer {
+	if size == 0 {
+		return nil
+	}
+	buf := make([]byte, size)
+	ptr := unsafe.Pointer(&buf[0])
+	wasmAllocations[toWasmPointer(ptr)] = buf
+	return ptr
+}
+
+//go:wasmexport free
+func wasmFree(ptr unsafe.Pointer) {
+	delete(wasmAllocations, toWasmPointer(ptr))
+}